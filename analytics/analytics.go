@@ -0,0 +1,492 @@
+// Package analytics computes SaaS metrics such as churn, ARPU, LTV and
+// cohort retention from raw Creem transactions and subscriptions.
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go"
+)
+
+// Dataset is the raw data metrics are computed from.
+type Dataset struct {
+	Transactions  []creemio.Transaction
+	Subscriptions []creemio.Subscription
+	// Products resolves subscriptions that only carry a product ID.
+	Products []creemio.Product
+}
+
+// Fetch loads the transactions matching query on every page and the
+// subscriptions they belong to. The API cannot list subscriptions, so
+// subscriptions without transactions are not included.
+func Fetch(ctx context.Context, c *creemio.Client, query *creemio.TransactionListQuery) (*Dataset, error) {
+	var data Dataset
+	var ids []string
+	seen := make(map[string]bool)
+
+	for t, err := range c.Transactions.All(ctx, query) {
+		if err != nil {
+			return nil, err
+		}
+		data.Transactions = append(data.Transactions, t)
+		if len(t.Subscription) > 0 && !seen[t.Subscription] {
+			seen[t.Subscription] = true
+			ids = append(ids, t.Subscription)
+		}
+	}
+
+	for _, r := range c.Subscriptions.GetMany(ctx, ids, nil) {
+		if r.Err != nil {
+			return nil, fmt.Errorf("analytics: subscription %s: %w", r.ID, r.Err)
+		}
+		data.Subscriptions = append(data.Subscriptions, *r.Value)
+	}
+	return &data, nil
+}
+
+type Options struct {
+	// Start and End bound the period churn, expansion, contraction and
+	// trials are measured over. End defaults to now and Start to one month
+	// before End.
+	Start time.Time
+	End   time.Time
+}
+
+// Metrics are the metrics of one currency or product. Amounts are in the
+// smallest currency unit.
+type Metrics struct {
+	Currency string
+	// MRR is the monthly recurring revenue of the subscriptions paying at
+	// End, with longer billing periods spread over their months. Paused and
+	// unpaid subscriptions stopped paying when they were last updated.
+	MRR                 int
+	ActiveSubscriptions int
+	ActiveCustomers     int
+	// ARPU is MRR per active customer.
+	ARPU float64
+	// ChurnRate is the share of the subscriptions paying at Start that
+	// ended before End.
+	ChurnRate float64
+	// LTV is ARPU divided by the churn rate per month, zero without churn.
+	LTV float64
+	// ExpansionMRR and ContractionMRR sum the monthly increases and
+	// decreases between consecutive charges of a subscription within the
+	// period. ContractionMRR is positive.
+	ExpansionMRR   int
+	ContractionMRR int
+	// Trials started within the period, ConvertedTrials of them were
+	// charged afterwards.
+	Trials          int
+	ConvertedTrials int
+	// TrialConversion is ConvertedTrials divided by the trials that have
+	// ended, trials still running are not counted.
+	TrialConversion float64
+
+	customers  map[string]bool
+	startCount int
+	churned    int
+	trialing   int
+}
+
+// Cohort is the retention of the subscriptions started in one month.
+type Cohort struct {
+	// Month is the first day of the month in UTC.
+	Month time.Time
+	Size  int
+	// Retained[k] is the number of subscriptions still running at the end
+	// of the k-th month after Month, the last entry is cut off at End.
+	Retained []int
+}
+
+// Retention returns the share of the cohort still running k months after
+// Month.
+func (c *Cohort) Retention(k int) float64 {
+	if c.Size == 0 || k < 0 || k >= len(c.Retained) {
+		return 0
+	}
+	return float64(c.Retained[k]) / float64(c.Size)
+}
+
+type Report struct {
+	Start time.Time
+	End   time.Time
+	// Currencies and Products are keyed by the upper-case currency and the
+	// product ID.
+	Currencies map[string]*Metrics
+	Products   map[string]*Metrics
+	// Cohorts are in chronological order.
+	Cohorts []Cohort
+	// Skipped lists the subscriptions left out of every metric because the
+	// length of their billing period is unknown.
+	Skipped []Skipped
+}
+
+// Skipped is a subscription left out of a report.
+type Skipped struct {
+	SubscriptionID string
+	Reason         string
+}
+
+// subscription is a subscription with everything needed to compute its
+// metrics resolved.
+type subscription struct {
+	*creemio.Subscription
+	productID string
+	currency  string
+	months    int
+	mrr       int
+	end       *time.Time
+	charges   []*creemio.Transaction
+}
+
+// paying reports whether s was paying at t.
+func (s *subscription) paying(t time.Time) bool {
+	if s.CreatedAt.After(t) {
+		return false
+	}
+	if s.end != nil && !s.end.After(t) {
+		return false
+	}
+	return !s.trial() || s.converted(t)
+}
+
+// trial reports whether s started with a free trial: it is still trialing
+// or its first charge was free without a discount.
+func (s *subscription) trial() bool {
+	if s.Status == creemio.SubscriptionStatusTrialing {
+		return true
+	}
+	if len(s.charges) == 0 {
+		return false
+	}
+	first := s.charges[0]
+	return first.AmountPaid == 0 && first.DiscountAmount == 0
+}
+
+// converted reports whether s was charged after its trial by t.
+func (s *subscription) converted(t time.Time) bool {
+	for _, c := range s.charges {
+		if c.AmountPaid > 0 && !c.CreatedTime().After(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// monthly spreads a charge over the months of the billing period.
+func (s *subscription) monthly(amount int) int {
+	return (amount + s.months/2) / s.months
+}
+
+// Compute computes the metrics of data. Subscriptions are assigned to the
+// currency and product of their product. Without a price, MRR falls back to
+// the last charge of the subscription. Subscriptions whose billing period is
+// unknown are listed in Report.Skipped instead of being counted as monthly.
+func Compute(data *Dataset, opts *Options) *Report {
+	report := &Report{
+		Currencies: make(map[string]*Metrics),
+		Products:   make(map[string]*Metrics),
+	}
+	if opts != nil {
+		report.Start, report.End = opts.Start, opts.End
+	}
+	if report.End.IsZero() {
+		report.End = time.Now()
+	}
+	if report.Start.IsZero() {
+		report.Start = report.End.AddDate(0, -1, 0)
+	}
+	if data == nil {
+		return report
+	}
+
+	subs, skipped := resolve(data)
+	report.Skipped = skipped
+	for _, s := range subs {
+		for _, m := range []*Metrics{
+			segment(report.Currencies, s.currency, s.currency),
+			segment(report.Products, s.productID, s.currency),
+		} {
+			m.add(s, report.Start, report.End)
+		}
+	}
+	for _, m := range report.Currencies {
+		m.finish(report.Start, report.End)
+	}
+	for _, m := range report.Products {
+		m.finish(report.Start, report.End)
+	}
+	report.Cohorts = cohorts(subs, report.End)
+	return report
+}
+
+func segment(segments map[string]*Metrics, key, currency string) *Metrics {
+	m, ok := segments[key]
+	if !ok {
+		m = &Metrics{Currency: currency, customers: make(map[string]bool)}
+		segments[key] = m
+	}
+	return m
+}
+
+// resolve joins the subscriptions with their products and charges.
+// Subscriptions without a known billing period are skipped, as their MRR
+// cannot be told apart from a monthly one.
+func resolve(data *Dataset) ([]*subscription, []Skipped) {
+	products := make(map[string]*creemio.Product, len(data.Products))
+	for i := range data.Products {
+		products[data.Products[i].ID] = &data.Products[i]
+	}
+
+	charges := make(map[string][]*creemio.Transaction)
+	for i := range data.Transactions {
+		t := &data.Transactions[i]
+		if len(t.Subscription) > 0 && t.Status == creemio.TransactionStatusPaid {
+			charges[t.Subscription] = append(charges[t.Subscription], t)
+		}
+	}
+
+	subs := make([]*subscription, 0, len(data.Subscriptions))
+	var skipped []Skipped
+	for i := range data.Subscriptions {
+		s := &subscription{Subscription: &data.Subscriptions[i]}
+
+		product := s.Product
+		if product != nil && len(product.Currency) == 0 {
+			if p, ok := products[product.ID]; ok {
+				product = p
+			}
+		}
+
+		s.charges = charges[s.ID]
+		slices.SortFunc(s.charges, func(a, b *creemio.Transaction) int {
+			return a.CreatedAt - b.CreatedAt
+		})
+
+		if product == nil {
+			skipped = append(skipped, Skipped{SubscriptionID: s.ID, Reason: "no product"})
+			continue
+		}
+		months, ok := creemio.BillingPeriodMonths(product.BillingPeriod)
+		if !ok {
+			skipped = append(skipped, Skipped{
+				SubscriptionID: s.ID,
+				Reason:         fmt.Sprintf("unknown billing period %q of product %s", product.BillingPeriod, product.ID),
+			})
+			continue
+		}
+		s.productID = product.ID
+		s.currency = product.Currency
+		s.months = months
+		if len(s.currency) == 0 && len(s.charges) > 0 {
+			s.currency = s.charges[len(s.charges)-1].Currency
+		}
+		s.currency = strings.ToUpper(s.currency)
+
+		switch {
+		case product.Price > 0:
+			s.mrr = s.monthly(product.Price * units(s.Subscription, product.ID))
+		case len(s.charges) > 0:
+			s.mrr = s.monthly(s.charges[len(s.charges)-1].Amount)
+		}
+
+		switch s.Status {
+		case creemio.SubscriptionStatusCanceled:
+			end := s.UpdatedAt
+			if s.CanceledAt != nil {
+				end = *s.CanceledAt
+			}
+			s.end = &end
+		case creemio.SubscriptionStatusScheduledCancel:
+			s.end = s.CurrentPeriodEndDate
+		case creemio.SubscriptionStatusPaused, creemio.SubscriptionStatusUnpaid:
+			// Only the current status is known, assume it has not changed
+			// since the last update.
+			end := s.UpdatedAt
+			s.end = &end
+		}
+
+		subs = append(subs, s)
+	}
+	return subs, skipped
+}
+
+// units returns the units of productID on the subscription, at least one.
+func units(s *creemio.Subscription, productID string) int {
+	n := 0
+	for _, item := range s.Items {
+		if item.ProductID == productID || len(item.ProductID) == 0 {
+			n += item.Units
+		}
+	}
+	return max(n, 1)
+}
+
+func (m *Metrics) add(s *subscription, start, end time.Time) {
+	if s.paying(end) {
+		m.MRR += s.mrr
+		m.ActiveSubscriptions++
+		if s.Customer != nil && len(s.Customer.ID) > 0 {
+			m.customers[s.Customer.ID] = true
+		}
+	}
+
+	if s.paying(start) {
+		m.startCount++
+		if !s.paying(end) {
+			m.churned++
+		}
+	}
+
+	for i := 1; i < len(s.charges); i++ {
+		at := s.charges[i].CreatedTime()
+		if at.Before(start) || !at.Before(end) {
+			continue
+		}
+		prev, cur := s.charges[i-1], s.charges[i]
+		if prev.AmountPaid == 0 && prev.DiscountAmount == 0 {
+			// The trial is not a contraction.
+			continue
+		}
+		delta := s.monthly(cur.Amount) - s.monthly(prev.Amount)
+		if delta > 0 {
+			m.ExpansionMRR += delta
+		} else {
+			m.ContractionMRR -= delta
+		}
+	}
+
+	if s.trial() && !s.CreatedAt.Before(start) && s.CreatedAt.Before(end) {
+		m.Trials++
+		switch {
+		case s.converted(end):
+			m.ConvertedTrials++
+		case s.Status == creemio.SubscriptionStatusTrialing:
+			m.trialing++
+		}
+	}
+}
+
+func (m *Metrics) finish(start, end time.Time) {
+	m.ActiveCustomers = len(m.customers)
+	if m.ActiveCustomers > 0 {
+		m.ARPU = float64(m.MRR) / float64(m.ActiveCustomers)
+	}
+	if m.startCount > 0 {
+		m.ChurnRate = float64(m.churned) / float64(m.startCount)
+	}
+	if ended := m.Trials - m.trialing; ended > 0 {
+		m.TrialConversion = float64(m.ConvertedTrials) / float64(ended)
+	}
+
+	// Convert the churn of the period to a monthly rate, assuming it is
+	// compounded over average months.
+	const month = 365.25 / 12 * 24
+	months := end.Sub(start).Hours() / month
+	if m.ChurnRate > 0 && months > 0 {
+		monthly := m.ChurnRate
+		if m.ChurnRate < 1 {
+			monthly = 1 - math.Pow(1-m.ChurnRate, 1/months)
+		}
+		m.LTV = m.ARPU / monthly
+	}
+}
+
+func cohorts(subs []*subscription, end time.Time) []Cohort {
+	byMonth := make(map[time.Time]*Cohort)
+	for _, s := range subs {
+		created := s.CreatedAt.UTC()
+		if created.After(end) {
+			continue
+		}
+		month := time.Date(created.Year(), created.Month(), 1, 0, 0, 0, 0, time.UTC)
+		c, ok := byMonth[month]
+		if !ok {
+			c = &Cohort{Month: month}
+			for at := month; at.Before(end); at = at.AddDate(0, 1, 0) {
+				c.Retained = append(c.Retained, 0)
+			}
+			byMonth[month] = c
+		}
+		c.Size++
+
+		for k := range c.Retained {
+			at := month.AddDate(0, k+1, 0)
+			if at.After(end) {
+				at = end
+			}
+			if s.end == nil || s.end.After(at) {
+				c.Retained[k]++
+			}
+		}
+	}
+
+	result := make([]Cohort, 0, len(byMonth))
+	for _, c := range byMonth {
+		result = append(result, *c)
+	}
+	slices.SortFunc(result, func(a, b Cohort) int {
+		return a.Month.Compare(b.Month)
+	})
+	return result
+}
+
+// Discrepancy is a difference between the computed MRR and the MRR reported
+// by the stats endpoint.
+type Discrepancy struct {
+	Currency string
+	Computed int
+	Reported float64
+	// Difference is Computed minus Reported.
+	Difference float64
+}
+
+// Compare compares the computed MRR of currency with totals. It returns nil
+// when they differ by at most tolerance, a fraction of the reported MRR.
+func (r *Report) Compare(currency creemio.Currency, totals *creemio.Totals, tolerance float64) *Discrepancy {
+	computed := 0
+	if m, ok := r.Currencies[strings.ToUpper(string(currency))]; ok {
+		computed = m.MRR
+	}
+	diff := float64(computed) - totals.MonthlyRecurringRevenue
+	if math.Abs(diff) <= tolerance*math.Abs(totals.MonthlyRecurringRevenue) {
+		return nil
+	}
+	return &Discrepancy{
+		Currency:   strings.ToUpper(string(currency)),
+		Computed:   computed,
+		Reported:   totals.MonthlyRecurringRevenue,
+		Difference: diff,
+	}
+}
+
+// Reconcile fetches the totals of every currency in the report and returns
+// the currencies whose MRR differs by more than tolerance.
+func (r *Report) Reconcile(ctx context.Context, stats *creemio.StatsService, tolerance float64) ([]Discrepancy, error) {
+	currencies := make([]string, 0, len(r.Currencies))
+	for currency := range r.Currencies {
+		if len(currency) > 0 {
+			currencies = append(currencies, currency)
+		}
+	}
+	slices.Sort(currencies)
+
+	var discrepancies []Discrepancy
+	for _, currency := range currencies {
+		summary, _, err := stats.GetMetricsSummary(ctx, &creemio.MetricsSummaryQuery{
+			Currency: creemio.Currency(currency),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("analytics: %s totals: %w", currency, err)
+		}
+		if d := r.Compare(creemio.Currency(currency), &summary.Totals, tolerance); d != nil {
+			discrepancies = append(discrepancies, *d)
+		}
+	}
+	return discrepancies, nil
+}
@@ -0,0 +1,159 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go"
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func charge(id, sub string, at time.Time, amount, paid int) creemio.Transaction {
+	return creemio.Transaction{
+		ID:           id,
+		Subscription: sub,
+		Amount:       amount,
+		AmountPaid:   paid,
+		Currency:     "USD",
+		Status:       creemio.TransactionStatusPaid,
+		CreatedAt:    int(at.UnixMilli()),
+	}
+}
+
+func newDataset() *Dataset {
+	monthly := creemio.Product{ID: "prod_month", Price: 1000, Currency: "USD", BillingPeriod: "every-month"}
+	yearly := creemio.Product{ID: "prod_year", Price: 12000, Currency: "USD", BillingPeriod: "every-year"}
+	quarterly := creemio.Product{ID: "prod_quarter", Price: 3000, Currency: "USD", BillingPeriod: "every-three-months"}
+	semiannual := creemio.Product{ID: "prod_half", Price: 6000, Currency: "USD", BillingPeriod: "every-six-months"}
+	unknown := creemio.Product{ID: "prod_unknown", Price: 3000, Currency: "USD", BillingPeriod: "every-3-months"}
+	euro := creemio.Product{ID: "prod_eur", Price: 500, Currency: "EUR", BillingPeriod: "every-month"}
+	canceled := date(2025, 2, 10)
+
+	return &Dataset{
+		Subscriptions: []creemio.Subscription{
+			{ID: "sub_1", Product: &monthly, Customer: &creemio.Customer{ID: "cust_1"}, Status: creemio.SubscriptionStatusActive, CreatedAt: date(2024, 12, 10)},
+			{ID: "sub_2", Product: &yearly, Customer: &creemio.Customer{ID: "cust_1"}, Status: creemio.SubscriptionStatusActive, CreatedAt: date(2025, 1, 5)},
+			{ID: "sub_3", Product: &monthly, Customer: &creemio.Customer{ID: "cust_2"}, Status: creemio.SubscriptionStatusCanceled, CreatedAt: date(2025, 1, 15), CanceledAt: &canceled},
+			{ID: "sub_4", Product: &monthly, Customer: &creemio.Customer{ID: "cust_3"}, Status: creemio.SubscriptionStatusTrialing, CreatedAt: date(2025, 2, 20)},
+			{ID: "sub_5", Product: &monthly, Customer: &creemio.Customer{ID: "cust_4"}, Status: creemio.SubscriptionStatusActive, CreatedAt: date(2025, 2, 3)},
+			// Only the product ID, resolved through Products.
+			{ID: "sub_6", Product: &creemio.Product{ID: "prod_eur"}, Customer: &creemio.Customer{ID: "cust_5"}, Status: creemio.SubscriptionStatusActive, CreatedAt: date(2025, 1, 1),
+				Items: []creemio.SubscriptionItem{{ProductID: "prod_eur", Units: 2}}},
+			{ID: "sub_7", Product: &quarterly, Customer: &creemio.Customer{ID: "cust_6"}, Status: creemio.SubscriptionStatusActive, CreatedAt: date(2024, 12, 1)},
+			{ID: "sub_8", Product: &semiannual, Customer: &creemio.Customer{ID: "cust_6"}, Status: creemio.SubscriptionStatusActive, CreatedAt: date(2024, 12, 1)},
+			// Stopped paying within the period.
+			{ID: "sub_10", Product: &monthly, Customer: &creemio.Customer{ID: "cust_8"}, Status: creemio.SubscriptionStatusPaused, CreatedAt: date(2024, 12, 1), UpdatedAt: date(2025, 2, 15)},
+			{ID: "sub_11", Product: &monthly, Customer: &creemio.Customer{ID: "cust_9"}, Status: creemio.SubscriptionStatusUnpaid, CreatedAt: date(2024, 12, 1), UpdatedAt: date(2025, 2, 20)},
+			// Not counted as monthly.
+			{ID: "sub_9", Product: &unknown, Customer: &creemio.Customer{ID: "cust_7"}, Status: creemio.SubscriptionStatusActive, CreatedAt: date(2024, 12, 1)},
+		},
+		Transactions: []creemio.Transaction{
+			charge("txn_2", "sub_1", date(2025, 2, 10), 1500, 1500),
+			charge("txn_1", "sub_1", date(2025, 1, 10), 1000, 1000),
+			charge("txn_3", "sub_5", date(2025, 2, 3), 0, 0),
+			charge("txn_4", "sub_5", date(2025, 2, 17), 1000, 1000),
+		},
+		Products: []creemio.Product{euro},
+	}
+}
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	report := Compute(newDataset(), &Options{Start: date(2025, 2, 1), End: date(2025, 3, 1)})
+
+	usd := report.Currencies["USD"]
+	a.Equal(5000, usd.MRR)
+	a.Equal(5, usd.ActiveSubscriptions)
+	a.Equal(3, usd.ActiveCustomers)
+	a.InDelta(5000.0/3, usd.ARPU, 1e-9)
+	// sub_3, sub_10 and sub_11 of the seven paying at Start.
+	a.InDelta(3.0/7, usd.ChurnRate, 1e-9)
+	a.InDelta(3657.0, usd.LTV, 0.1)
+	a.Equal(500, usd.ExpansionMRR)
+	a.Equal(0, usd.ContractionMRR)
+	a.Equal(2, usd.Trials)
+	a.Equal(1, usd.ConvertedTrials)
+	a.Equal(1.0, usd.TrialConversion)
+
+	eur := report.Currencies["EUR"]
+	a.Equal(1000, eur.MRR)
+	a.Zero(eur.ChurnRate)
+	a.Zero(eur.LTV)
+
+	a.Equal(1000, report.Products["prod_year"].MRR)
+	a.Equal(2000, report.Products["prod_month"].MRR)
+	a.Equal(1000, report.Products["prod_quarter"].MRR)
+	a.Equal(1000, report.Products["prod_half"].MRR)
+	a.NotContains(report.Products, "prod_unknown")
+	a.Equal([]Skipped{{SubscriptionID: "sub_9", Reason: `unknown billing period "every-3-months" of product prod_unknown`}}, report.Skipped)
+	a.Equal("EUR", report.Products["prod_eur"].Currency)
+
+	a.Len(report.Cohorts, 3)
+	a.Equal(Cohort{Month: date(2024, 12, 1), Size: 5, Retained: []int{5, 5, 3}}, report.Cohorts[0])
+	a.Equal(Cohort{Month: date(2025, 1, 1), Size: 3, Retained: []int{3, 2}}, report.Cohorts[1])
+	a.InDelta(2.0/3, report.Cohorts[1].Retention(1), 1e-9)
+	a.Zero(report.Cohorts[1].Retention(2))
+}
+
+func TestCompute_Empty(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	report := Compute(nil, nil)
+	a.False(report.End.IsZero())
+	a.Equal(report.End.AddDate(0, -1, 0), report.Start)
+	a.Empty(report.Currencies)
+}
+
+func TestReport_Compare(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	report := Compute(newDataset(), &Options{Start: date(2025, 2, 1), End: date(2025, 3, 1)})
+
+	a.Nil(report.Compare(creemio.CurrencyUSD, &creemio.Totals{MonthlyRecurringRevenue: 5040}, 0.01))
+	a.Equal(&Discrepancy{
+		Currency:   "USD",
+		Computed:   5000,
+		Reported:   5500,
+		Difference: -500,
+	}, report.Compare(creemio.CurrencyUSD, &creemio.Totals{MonthlyRecurringRevenue: 5500}, 0.01))
+}
+
+func TestFetchAndReconcile(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET /%s/transactions/search", creemio.APIVersion), mock.HandleGetTransactionList)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/subscriptions", creemio.APIVersion), mock.HandleGetSubscription)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/stats/summary", creemio.APIVersion), mock.HandleGetMetricsSummary)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c := creemio.New(creemio.WithBaseURL(s.URL))
+
+	data, err := Fetch(context.Background(), c, nil)
+	a.NoError(err)
+	a.Len(data.Transactions, 2)
+	a.NotEmpty(data.Subscriptions)
+
+	report := Compute(data, nil)
+	a.Contains(report.Currencies, "EUR")
+
+	discrepancies, err := report.Reconcile(context.Background(), c.Stats, 0.01)
+	a.NoError(err)
+	a.Len(discrepancies, 1)
+	a.Equal(Discrepancy{Currency: "EUR", Computed: 98400, Reported: 94200, Difference: 4200}, discrepancies[0])
+}
@@ -0,0 +1,156 @@
+package creemio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// RedactedValue replaces redacted payload values in audit entries.
+const RedactedValue = "[REDACTED]"
+
+// DefaultAuditRedactedFields are the payload fields redacted by default.
+var DefaultAuditRedactedFields = []string{"email", "key"}
+
+// AuditEntry records a single call that changed, or attempted to change,
+// billing state.
+type AuditEntry struct {
+	Time       time.Time       `json:"time"`
+	Service    string          `json:"service"`
+	Action     string          `json:"action"`
+	Method     string          `json:"method"`
+	URL        string          `json:"url"`
+	ResourceID string          `json:"resource_id,omitempty"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	Status     int             `json:"status,omitempty"`
+	TraceID    string          `json:"trace_id,omitempty"`
+	Actor      string          `json:"actor,omitempty"`
+	DryRun     bool            `json:"dry_run,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// AuditSink stores audit entries. Record is called synchronously after each
+// mutating call and must be safe for concurrent use.
+type AuditSink interface {
+	Record(ctx context.Context, entry *AuditEntry) error
+}
+
+type actorKey struct{}
+
+// ContextWithActor attaches the identity of the caller, e.g. a user ID or
+// service name, to the audit entries of calls made with ctx.
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the caller identity set by ContextWithActor.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// audit records the outcome of a mutating call. The response body is
+// buffered so it can still be read by the caller.
+func (c *Client) audit(req *http.Request, m mutation, res *http.Response, callErr error) {
+	entry := &AuditEntry{
+		Time:       time.Now().UTC(),
+		Service:    m.service,
+		Action:     m.action,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		ResourceID: m.resourceID,
+		Actor:      ActorFromContext(req.Context()),
+		DryRun:     c.dryRun,
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			if payload, err := io.ReadAll(body); err == nil && len(payload) > 0 {
+				entry.Payload = redactPayload(payload, c.auditRedactedFields)
+			}
+		}
+	}
+
+	if callErr != nil {
+		entry.Error = callErr.Error()
+	}
+	if res != nil {
+		entry.Status = res.StatusCode
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			entry.Error = err.Error()
+		}
+
+		var obj struct {
+			ID      string `json:"id"`
+			TraceID string `json:"trace_id"`
+			Err     string `json:"error"`
+		}
+		if json.Unmarshal(body, &obj) == nil {
+			entry.TraceID = obj.TraceID
+			if len(entry.ResourceID) == 0 && res.StatusCode < 400 {
+				entry.ResourceID = obj.ID
+			}
+			if res.StatusCode >= 400 && len(entry.Error) == 0 {
+				entry.Error = obj.Err
+			}
+		}
+	}
+
+	if err := c.auditSink.Record(req.Context(), entry); err != nil {
+		c.auditErrorHandler(entry, err)
+	}
+}
+
+func defaultAuditErrorHandler(entry *AuditEntry, err error) {
+	slog.Default().Error("creemio: failed to record audit entry",
+		"service", entry.Service,
+		"action", entry.Action,
+		"resource_id", entry.ResourceID,
+		"error", err,
+	)
+}
+
+// redactPayload replaces the values of the given fields at any depth of a
+// JSON payload. Payloads that are not valid JSON are dropped entirely.
+func redactPayload(payload []byte, fields []string) json.RawMessage {
+	var v any
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return json.RawMessage(`"` + RedactedValue + `"`)
+	}
+
+	redact := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		redact[f] = true
+	}
+
+	out, err := json.Marshal(redactValue(v, redact))
+	if err != nil {
+		return json.RawMessage(`"` + RedactedValue + `"`)
+	}
+	return out
+}
+
+func redactValue(v any, fields map[string]bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if fields[k] {
+				v[k] = RedactedValue
+			} else {
+				v[k] = redactValue(val, fields)
+			}
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = redactValue(val, fields)
+		}
+	}
+	return v
+}
@@ -0,0 +1,216 @@
+package creemio
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrAuditChainBroken = errors.New("audit log hash chain is broken")
+
+// maxAuditLine bounds the size of a single audit record when reading logs back.
+const maxAuditLine = 4 << 20
+
+// auditRecord is an audit entry as written by JSONLAuditSink. Hash is the
+// SHA-256 of the record encoded without Hash, and PrevHash links it to the
+// record before it, so any edit or removal breaks the chain.
+type auditRecord struct {
+	AuditEntry
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash,omitempty"`
+}
+
+func (r *auditRecord) computeHash() (string, error) {
+	unsigned := *r
+	unsigned.Hash = ""
+	data, err := json.Marshal(&unsigned)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// JSONLAuditSink appends hash chained audit records to a JSON lines file.
+// Once the file grows beyond maxBytes it is renamed with a timestamp suffix
+// and a new file is started, the chain continues across files.
+type JSONLAuditSink struct {
+	path     string
+	maxBytes int64
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	lastHash string
+}
+
+// NewJSONLAuditSink opens or creates the audit log at path. A maxBytes of 0
+// disables rotation. If the file already exists the chain is continued from
+// its last record.
+func NewJSONLAuditSink(path string, maxBytes int64) (*JSONLAuditSink, error) {
+	s := &JSONLAuditSink{
+		path:     path,
+		maxBytes: maxBytes,
+	}
+
+	lastHash, err := lastAuditHash(path)
+	if err != nil {
+		return nil, err
+	}
+	s.lastHash = lastHash
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *JSONLAuditSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
+
+func (s *JSONLAuditSink) Record(ctx context.Context, entry *AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return os.ErrClosed
+	}
+
+	rec := auditRecord{AuditEntry: *entry, PrevHash: s.lastHash}
+	hash, err := rec.computeHash()
+	if err != nil {
+		return err
+	}
+	rec.Hash = hash
+
+	line, err := json.Marshal(&rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if s.maxBytes > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return err
+	}
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+
+	s.lastHash = hash
+	return nil
+}
+
+func (s *JSONLAuditSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file = nil
+
+	rotated := fmt.Sprintf("%s.%s", s.path, time.Now().UTC().Format("20060102T150405.000000000Z"))
+	if err := os.Rename(s.path, rotated); err != nil {
+		return err
+	}
+	return s.open()
+}
+
+// Close closes the underlying file, further calls to Record fail.
+func (s *JSONLAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func lastAuditHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var last string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxAuditLine)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return "", err
+		}
+		last = rec.Hash
+	}
+	return last, scanner.Err()
+}
+
+// VerifyAuditLog checks the hash chain of an audit log written by
+// JSONLAuditSink. prevHash is the hash of the last record of the preceding
+// file, empty for the first file. It returns the hash of the last record so
+// rotated files can be verified in order.
+func VerifyAuditLog(r io.Reader, prevHash string) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxAuditLine)
+
+	var line int
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var rec auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return "", fmt.Errorf("line %d: %w", line, err)
+		}
+		if rec.PrevHash != prevHash {
+			return "", fmt.Errorf("line %d: %w: previous hash mismatch", line, ErrAuditChainBroken)
+		}
+		hash, err := rec.computeHash()
+		if err != nil {
+			return "", fmt.Errorf("line %d: %w", line, err)
+		}
+		if hash != rec.Hash {
+			return "", fmt.Errorf("line %d: %w: record was modified", line, ErrAuditChainBroken)
+		}
+		prevHash = hash
+	}
+	return prevHash, scanner.Err()
+}
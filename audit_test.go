@@ -0,0 +1,151 @@
+package creemio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryAuditSink struct {
+	mu      sync.Mutex
+	entries []*AuditEntry
+}
+
+func (s *memoryAuditSink) Record(ctx context.Context, entry *AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func TestAudit_RecordsMutations(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandlePostCheckout))
+	defer s.Close()
+
+	sink := &memoryAuditSink{}
+	c := New(
+		WithBaseURL(s.URL),
+		WithAuditSink(sink),
+	)
+
+	ctx := ContextWithActor(context.Background(), "user:42")
+	resp, _, err := c.Checkouts.Create(ctx, &CheckoutCreateRequest{
+		ProductID: "prod_987654321",
+		Customer:  &CheckoutCustomer{Email: "user@example.com"},
+	})
+	a.NoError(err)
+	a.Equal("ch_1234567890", resp.ID)
+
+	// Reads are not audited.
+	_, _, err = c.Checkouts.Get(ctx, "ch_1234567890")
+	a.NoError(err)
+
+	a.Len(sink.entries, 1)
+	entry := sink.entries[0]
+	a.Equal("checkouts", entry.Service)
+	a.Equal("create", entry.Action)
+	a.Equal(http.MethodPost, entry.Method)
+	a.Equal("ch_1234567890", entry.ResourceID)
+	a.Equal("user:42", entry.Actor)
+	a.Equal(http.StatusOK, entry.Status)
+	a.JSONEq(`{"product_id": "prod_987654321", "customer": {"email": "[REDACTED]"}}`, string(entry.Payload))
+}
+
+func TestAudit_RecordsFailures(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"trace_id": "trace_123", "status": 400, "error": "Bad Request"}`))
+	}))
+	defer s.Close()
+
+	sink := &memoryAuditSink{}
+	c := New(
+		WithBaseURL(s.URL),
+		WithMode(ModeProduction),
+		WithProductionGuard(),
+		WithAuditSink(sink),
+	)
+
+	_, _, err := c.Subscriptions.Cancel(context.Background(), "sub_123")
+	a.ErrorIs(err, ErrProductionMutation)
+
+	_, _, err = c.Discounts.Delete(AllowProductionMutations(context.Background()), "dis_123")
+	a.Error(err)
+
+	a.Len(sink.entries, 2)
+	a.Equal("sub_123", sink.entries[0].ResourceID)
+	a.Equal(ErrProductionMutation.Error(), sink.entries[0].Error)
+	a.Zero(sink.entries[0].Status)
+
+	a.Equal("dis_123", sink.entries[1].ResourceID)
+	a.Equal(http.StatusBadRequest, sink.entries[1].Status)
+	a.Equal("trace_123", sink.entries[1].TraceID)
+	a.Equal("Bad Request", sink.entries[1].Error)
+}
+
+func TestJSONLAuditSink(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.jsonl")
+
+	sink, err := NewJSONLAuditSink(path, 600)
+	a.NoError(err)
+
+	for _, id := range []string{"sub_1", "sub_2", "sub_3", "sub_4"} {
+		a.NoError(sink.Record(context.Background(), &AuditEntry{
+			Service:    "subscriptions",
+			Action:     "cancel",
+			ResourceID: id,
+			Payload:    json.RawMessage(`{"a":1}`),
+		}))
+	}
+	a.NoError(sink.Close())
+
+	// Reopening continues the chain.
+	sink, err = NewJSONLAuditSink(path, 600)
+	a.NoError(err)
+	a.NoError(sink.Record(context.Background(), &AuditEntry{Service: "discounts", Action: "delete"}))
+	a.NoError(sink.Close())
+
+	files, err := filepath.Glob(path + "*")
+	a.NoError(err)
+	a.Greater(len(files), 1)
+
+	// Rotated files sort by timestamp and are verified before the active file.
+	var prevHash string
+	for _, f := range files[1:] {
+		data, err := os.ReadFile(f)
+		a.NoError(err)
+		prevHash, err = VerifyAuditLog(bytes.NewReader(data), prevHash)
+		a.NoError(err)
+	}
+	a.NotEmpty(prevHash)
+
+	data, err := os.ReadFile(path)
+	a.NoError(err)
+	lastHash, err := VerifyAuditLog(bytes.NewReader(data), prevHash)
+	a.NoError(err)
+	a.NotEmpty(lastHash)
+
+	tampered := strings.Replace(string(data), `"discounts"`, `"products"`, 1)
+	_, err = VerifyAuditLog(strings.NewReader(tampered), prevHash)
+	a.ErrorIs(err, ErrAuditChainBroken)
+}
@@ -0,0 +1,106 @@
+package creemio
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of requests GetMany keeps in flight
+// unless BatchOptions says otherwise.
+const DefaultBatchConcurrency = 8
+
+// Limiter paces requests. *rate.Limiter from golang.org/x/time/rate
+// satisfies it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+type BatchOptions struct {
+	// Concurrency is the maximum number of requests in flight.
+	Concurrency int
+	// Limiter, if set, is waited on before each request.
+	Limiter Limiter
+}
+
+// BatchResult is the outcome of fetching a single ID. Exactly one of Value
+// and Err is set.
+type BatchResult[T any] struct {
+	ID       string
+	Value    *T
+	Response *Response
+	Err      error
+}
+
+// getMany calls get for every ID with bounded parallelism. Results are in
+// the order of ids, a failed ID does not stop the others.
+func getMany[T any](ctx context.Context, ids []string, opts *BatchOptions, get func(context.Context, string) (*T, *Response, error)) []BatchResult[T] {
+	concurrency := DefaultBatchConcurrency
+	var limiter Limiter
+	if opts != nil {
+		if opts.Concurrency > 0 {
+			concurrency = opts.Concurrency
+		}
+		limiter = opts.Limiter
+	}
+
+	results := make([]BatchResult[T], len(ids))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		results[i].ID = id
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			r := &results[i]
+			if limiter != nil {
+				if err := limiter.Wait(ctx); err != nil {
+					r.Err = err
+					return
+				}
+			}
+			r.Value, r.Response, r.Err = get(ctx, id)
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+// paginate iterates over the items of every page, starting at page start or
+// the first page. list fetches one page, iteration follows NextPage and stops
+// at the first error.
+func paginate[T any](start int, list func(page int) ([]T, Pagination, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		page := max(start, 1)
+		for {
+			items, pagination, err := list(page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if pagination.NextPage <= page {
+				return
+			}
+			page = pagination.NextPage
+		}
+	}
+}
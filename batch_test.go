@@ -0,0 +1,126 @@
+package creemio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingLimiter struct {
+	calls atomic.Int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.calls.Add(1)
+	return ctx.Err()
+}
+
+func TestGetMany(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var inFlight, maxInFlight atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if r.URL.Query().Get("subscription_id") == "sub_missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"trace_id": "trace_404", "status": 404, "error": "Not Found"}`))
+			return
+		}
+		mock.HandleGetSubscription(w, r)
+	}))
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+	limiter := &countingLimiter{}
+	ids := []string{"sub_1", "sub_2", "sub_missing", "sub_3", "sub_4", "sub_5"}
+
+	results := c.Subscriptions.GetMany(context.Background(), ids, &BatchOptions{
+		Concurrency: 2,
+		Limiter:     limiter,
+	})
+
+	a.Len(results, len(ids))
+	for i, r := range results {
+		a.Equal(ids[i], r.ID)
+		if r.ID == "sub_missing" {
+			a.Nil(r.Value)
+			var apiErr *APIError
+			a.ErrorAs(r.Err, &apiErr)
+			continue
+		}
+		a.NoError(r.Err)
+		a.NotNil(r.Value)
+	}
+	a.LessOrEqual(maxInFlight.Load(), int32(2))
+	a.EqualValues(len(ids), limiter.calls.Load())
+}
+
+func TestGetMany_Canceled(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandleGetProduct))
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := c.Products.GetMany(ctx, []string{"prod_1", "prod_2"}, nil)
+	a.Len(results, 2)
+	for _, r := range results {
+		a.ErrorIs(r.Err, context.Canceled)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	errPage := errors.New("page 3 failed")
+	var requested []int
+	list := func(page int) ([]int, Pagination, error) {
+		requested = append(requested, page)
+		if page == 3 {
+			return nil, Pagination{}, errPage
+		}
+		return []int{page * 10, page*10 + 1}, Pagination{CurrentPage: page, NextPage: page + 1}, nil
+	}
+
+	var items []int
+	var err error
+	for item, e := range paginate(0, list) {
+		if e != nil {
+			err = e
+			break
+		}
+		items = append(items, item)
+	}
+	a.ErrorIs(err, errPage)
+	a.Equal([]int{10, 11, 20, 21}, items)
+	a.Equal([]int{1, 2, 3}, requested)
+
+	// Stopping early does not fetch further pages.
+	requested = nil
+	for range paginate(2, list) {
+		break
+	}
+	a.Equal([]int{2}, requested)
+}
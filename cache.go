@@ -0,0 +1,239 @@
+package creemio
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheHeader is set to "HIT" on responses served from the cache.
+const CacheHeader = "X-Creemio-Cache"
+
+// DefaultCacheSize is the capacity of the LRU cache used when WithCache is
+// given no backend.
+const DefaultCacheSize = 1000
+
+// Cache stores response bodies of read calls. Implementations must be safe
+// for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(key string)
+}
+
+// CacheTTL sets how long responses are cached per resource. Resources with a
+// zero TTL are not cached.
+type CacheTTL struct {
+	Products  time.Duration
+	Discounts time.Duration
+	Customers time.Duration
+}
+
+// DefaultCacheTTL caches products for five minutes and discounts and
+// customers for one minute.
+var DefaultCacheTTL = CacheTTL{
+	Products:  5 * time.Minute,
+	Discounts: time.Minute,
+	Customers: time.Minute,
+}
+
+func (t CacheTTL) forResource(resource string) time.Duration {
+	switch resource {
+	case "products":
+		return t.Products
+	case "discounts":
+		return t.Discounts
+	case "customers":
+		return t.Customers
+	}
+	return 0
+}
+
+type noCacheKey struct{}
+
+// WithoutCache returns a context whose calls skip the cache lookup. The fresh
+// response still replaces the cached one.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+type responseCache struct {
+	backend Cache
+	ttl     CacheTTL
+
+	mu sync.Mutex
+	// generations are bumped to invalidate every cached entry of a resource.
+	generations map[string]uint64
+}
+
+// requestResource returns the resource a request URL belongs to, e.g.
+// "products" for /v1/products/search.
+func requestResource(req *http.Request) string {
+	path := strings.TrimPrefix(req.URL.Path, "/"+APIVersion+"/")
+	resource, _, _ := strings.Cut(path, "/")
+	return resource
+}
+
+func (rc *responseCache) generation(resource string) uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.generations[resource]
+}
+
+func (rc *responseCache) invalidate(resource string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generations[resource]++
+}
+
+// cacheKey identifies a request by resource generation, API key and URL, so
+// clients sharing a backend never see each other's data.
+func (c *Client) cacheKey(req *http.Request, resource string) string {
+	return resource + "|" +
+		strconv.FormatUint(c.cache.generation(resource), 10) + "|" +
+		c.keyFingerprint(req.Context()) + "|" +
+		req.URL.String()
+}
+
+// cachedResponse returns the cached response for a read request, if any.
+func (c *Client) cachedResponse(req *http.Request) (*http.Response, string, bool) {
+	if c.cache == nil || req.Method != http.MethodGet {
+		return nil, "", false
+	}
+	resource := requestResource(req)
+	if c.cache.ttl.forResource(resource) <= 0 {
+		return nil, "", false
+	}
+
+	key := c.cacheKey(req, resource)
+	if bypass, _ := req.Context().Value(noCacheKey{}).(bool); bypass {
+		return nil, key, false
+	}
+	body, ok := c.cache.backend.Get(key)
+	if !ok {
+		return nil, key, false
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+			CacheHeader:    []string{"HIT"},
+		},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, key, true
+}
+
+// storeResponse caches a successful response under key, buffering the body
+// so it can still be read by the caller.
+func (c *Client) storeResponse(req *http.Request, res *http.Response, key string) error {
+	if res.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.cache.backend.Set(key, body, c.cache.ttl.forResource(requestResource(req)))
+	return nil
+}
+
+// LRUCache is an in-memory Cache evicting the least recently used entries
+// once full.
+type LRUCache struct {
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity <= 0 {
+		capacity = DefaultCacheSize
+	}
+	return &LRUCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of entries, including expired ones not yet evicted.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,157 @@
+package creemio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_Reads(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var hits atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET /%s/products", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		mock.HandleGetProduct(w, r)
+	})
+	mux.HandleFunc(fmt.Sprintf("GET /%s/subscriptions", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		mock.HandleGetSubscription(w, r)
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithCache(nil, DefaultCacheTTL),
+	)
+	ctx := context.Background()
+
+	first, res, err := c.Products.Get(ctx, "prod_123")
+	a.NoError(err)
+	a.Empty(res.Headers.Get(CacheHeader))
+
+	second, res, err := c.Products.Get(ctx, "prod_123")
+	a.NoError(err)
+	a.Equal("HIT", res.Headers.Get(CacheHeader))
+	a.Equal(first, second)
+	a.EqualValues(1, hits.Load())
+
+	// Keyed by query.
+	_, _, err = c.Products.Get(ctx, "prod_456")
+	a.NoError(err)
+	a.EqualValues(2, hits.Load())
+
+	// Bypass skips the lookup.
+	_, res, err = c.Products.Get(WithoutCache(ctx), "prod_123")
+	a.NoError(err)
+	a.Empty(res.Headers.Get(CacheHeader))
+	a.EqualValues(3, hits.Load())
+
+	// Subscriptions are not cached.
+	for range 2 {
+		_, _, err = c.Subscriptions.Get(ctx, "sub_123")
+		a.NoError(err)
+	}
+	a.EqualValues(5, hits.Load())
+}
+
+func TestCache_InvalidatedByMutation(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var hits atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET /%s/discounts", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		mock.HandleGetDiscount(w, r)
+	})
+	mux.HandleFunc(fmt.Sprintf("DELETE /%s/discounts/{id}/delete", APIVersion), mock.HandleDeleteDiscount)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithCache(nil, DefaultCacheTTL),
+	)
+	ctx := context.Background()
+	query := &DiscountRequestQuery{DiscountID: "dis_123"}
+
+	for range 2 {
+		_, _, err := c.Discounts.Get(ctx, query)
+		a.NoError(err)
+	}
+	a.EqualValues(1, hits.Load())
+
+	_, _, err := c.Discounts.Delete(ctx, "dis_123")
+	a.NoError(err)
+
+	_, res, err := c.Discounts.Get(ctx, query)
+	a.NoError(err)
+	a.Empty(res.Headers.Get(CacheHeader))
+	a.EqualValues(2, hits.Load())
+}
+
+func TestCache_SeparatesAPIKeys(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var hits atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		mock.HandleGetProduct(w, r)
+	}))
+	defer s.Close()
+
+	backend := NewLRUCache(10)
+	for _, key := range []string{"creem_test_a", "creem_test_b"} {
+		c := New(
+			WithBaseURL(s.URL),
+			WithAPIKey(key),
+			WithCache(backend, DefaultCacheTTL),
+		)
+		_, _, err := c.Products.Get(context.Background(), "prod_123")
+		a.NoError(err)
+	}
+	a.EqualValues(2, hits.Load())
+	a.Equal(2, backend.Len())
+}
+
+func TestLRUCache(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	c := NewLRUCache(2)
+	c.Set("a", []byte("1"), time.Minute)
+	c.Set("b", []byte("2"), time.Minute)
+
+	_, ok := c.Get("a")
+	a.True(ok)
+
+	// b is the least recently used entry.
+	c.Set("c", []byte("3"), time.Minute)
+	_, ok = c.Get("b")
+	a.False(ok)
+	a.Equal(2, c.Len())
+
+	c.Set("d", []byte("4"), -time.Second)
+	_, ok = c.Get("d")
+	a.False(ok)
+
+	c.Delete("a")
+	_, ok = c.Get("a")
+	a.False(ok)
+
+	v, ok := c.Get("c")
+	a.True(ok)
+	a.Equal("3", string(v))
+}
@@ -91,7 +91,7 @@ func (s *CheckoutService) Get(ctx context.Context, id string) (*Checkout, *Respo
 	q.Set("checkout_id", id)
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -113,6 +113,12 @@ func (s *CheckoutService) Get(ctx context.Context, id string) (*Checkout, *Respo
 	return &checkout, newResponse(res, body), nil
 }
 
+// GetMany fetches the checkouts with the given IDs concurrently, see BatchOptions.
+// Results are in the order of ids, each with its own error.
+func (s *CheckoutService) GetMany(ctx context.Context, ids []string, opts *BatchOptions) []BatchResult[Checkout] {
+	return getMany(ctx, ids, opts, s.Get)
+}
+
 func (s *CheckoutService) Create(ctx context.Context, data *CheckoutCreateRequest) (*Checkout, *Response, error) {
 	targetUrl := makeUrl(s.client.baseURL, "/checkouts")
 
@@ -132,7 +138,13 @@ func (s *CheckoutService) Create(ctx context.Context, data *CheckoutCreateReques
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-api-key", s.client.apiKey)
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service: "checkouts",
+		action:  "create",
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateCreate(ctx, data)
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
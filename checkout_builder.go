@@ -0,0 +1,185 @@
+package creemio
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// MaxCheckoutCustomFields is the maximum number of custom fields a checkout session accepts.
+const MaxCheckoutCustomFields = 3
+
+// CheckoutBuilder builds a CheckoutCreateRequest and validates it client-side
+// so mistakes are reported before they become API errors.
+//
+//	req, err := creemio.NewCheckoutBuilder("prod_xxxxx").
+//		CustomerEmail("user@example.com").
+//		SuccessURL("https://example.com/thanks").
+//		Metadata("user_id", "u_123").
+//		Build()
+type CheckoutBuilder struct {
+	req CheckoutCreateRequest
+}
+
+func NewCheckoutBuilder(productID string) *CheckoutBuilder {
+	return &CheckoutBuilder{
+		req: CheckoutCreateRequest{ProductID: productID},
+	}
+}
+
+func (b *CheckoutBuilder) RequestID(id string) *CheckoutBuilder {
+	b.req.RequestID = id
+	return b
+}
+
+func (b *CheckoutBuilder) Units(units int) *CheckoutBuilder {
+	b.req.Units = units
+	return b
+}
+
+func (b *CheckoutBuilder) DiscountCode(code string) *CheckoutBuilder {
+	b.req.DiscountCode = code
+	return b
+}
+
+func (b *CheckoutBuilder) CustomerID(id string) *CheckoutBuilder {
+	if b.req.Customer == nil {
+		b.req.Customer = &CheckoutCustomer{}
+	}
+	b.req.Customer.ID = id
+	return b
+}
+
+func (b *CheckoutBuilder) CustomerEmail(email string) *CheckoutBuilder {
+	if b.req.Customer == nil {
+		b.req.Customer = &CheckoutCustomer{}
+	}
+	b.req.Customer.Email = email
+	return b
+}
+
+func (b *CheckoutBuilder) SuccessURL(u string) *CheckoutBuilder {
+	b.req.SuccessURL = u
+	return b
+}
+
+// CustomField appends fields to the checkout.
+func (b *CheckoutBuilder) CustomField(fields ...CustomField) *CheckoutBuilder {
+	b.req.CustomField = append(b.req.CustomField, fields...)
+	return b
+}
+
+// Metadata sets a single metadata entry, overwriting any previous value for key.
+func (b *CheckoutBuilder) Metadata(key string, value any) *CheckoutBuilder {
+	if b.req.Metadata == nil {
+		b.req.Metadata = make(map[string]any)
+	}
+	b.req.Metadata[key] = value
+	return b
+}
+
+// Validate checks every field and returns a *ValidationError listing all
+// problems found, or nil if the request is valid.
+func (b *CheckoutBuilder) Validate() error {
+	verr := &ValidationError{}
+	r := &b.req
+
+	if len(strings.TrimSpace(r.ProductID)) == 0 {
+		verr.add("product_id", "is required")
+	}
+	if r.Units < 0 {
+		verr.add("units", "must not be negative")
+	}
+	if r.Customer != nil {
+		if len(r.Customer.ID) > 0 && len(r.Customer.Email) > 0 {
+			verr.add("customer", "only one of id or email may be set")
+		}
+		if len(r.Customer.Email) > 0 {
+			if _, err := mail.ParseAddress(r.Customer.Email); err != nil {
+				verr.add("customer.email", "is not a valid email address")
+			}
+		}
+	}
+	if len(r.SuccessURL) > 0 {
+		u, err := url.Parse(r.SuccessURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			verr.add("success_url", "must be an absolute http(s) url")
+		}
+	}
+
+	if len(r.CustomField) > MaxCheckoutCustomFields {
+		verr.add("custom_field", fmt.Sprintf("at most %d custom fields are allowed", MaxCheckoutCustomFields))
+	}
+	seen := make(map[string]bool, len(r.CustomField))
+	for i, f := range r.CustomField {
+		field := fmt.Sprintf("custom_field[%d]", i)
+		f.validate(field+".", verr)
+		if len(f.Key) > 0 && seen[f.Key] {
+			verr.add(field+".key", fmt.Sprintf("duplicate key %q", f.Key))
+		}
+		seen[f.Key] = true
+	}
+
+	if len(r.Metadata) > MaxMetadataKeys {
+		verr.add("metadata", fmt.Sprintf("at most %d keys are allowed", MaxMetadataKeys))
+	}
+	for k, v := range r.Metadata {
+		if len(k) == 0 {
+			verr.add("metadata", "keys must not be empty")
+			continue
+		}
+		if len(k) > MaxMetadataKeyLength {
+			verr.add("metadata."+k, fmt.Sprintf("key must be at most %d characters", MaxMetadataKeyLength))
+		}
+		if _, err := json.Marshal(v); err != nil {
+			verr.add("metadata."+k, "value is not JSON serializable")
+		}
+	}
+
+	return verr.err()
+}
+
+// Build validates the builder and returns a copy of the request, ready to be
+// passed to CheckoutService.Create.
+func (b *CheckoutBuilder) Build() (*CheckoutCreateRequest, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b.req.clone(), nil
+}
+
+// CheckoutTemplate is a reusable checkout configuration, usually one per product.
+// Each call to Builder starts from an independent copy of the template.
+type CheckoutTemplate struct {
+	ProductID    string
+	Units        int
+	DiscountCode string
+	SuccessURL   string
+	CustomFields []CustomField
+	Metadata     map[string]any
+}
+
+func (t *CheckoutTemplate) Builder() *CheckoutBuilder {
+	b := NewCheckoutBuilder(t.ProductID)
+	b.req.Units = t.Units
+	b.req.DiscountCode = t.DiscountCode
+	b.req.SuccessURL = t.SuccessURL
+	b.req.CustomField = slices.Clone(t.CustomFields)
+	b.req.Metadata = maps.Clone(t.Metadata)
+	return b
+}
+
+func (r *CheckoutCreateRequest) clone() *CheckoutCreateRequest {
+	cp := *r
+	if r.Customer != nil {
+		customer := *r.Customer
+		cp.Customer = &customer
+	}
+	cp.CustomField = slices.Clone(r.CustomField)
+	cp.Metadata = maps.Clone(r.Metadata)
+	return &cp
+}
@@ -0,0 +1,115 @@
+package creemio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckoutBuilder_Build(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	req, err := NewCheckoutBuilder("prod_123").
+		RequestID("req_1").
+		Units(2).
+		CustomerEmail("user@example.com").
+		SuccessURL("https://example.com/return").
+		CustomField(CustomField{Type: "text", Key: "company", Label: "Company"}).
+		Metadata("userID", "user_123").
+		Build()
+
+	a.NoError(err)
+	a.Equal(&CheckoutCreateRequest{
+		RequestID:   "req_1",
+		ProductID:   "prod_123",
+		Units:       2,
+		Customer:    &CheckoutCustomer{Email: "user@example.com"},
+		SuccessURL:  "https://example.com/return",
+		CustomField: []CustomField{{Type: "text", Key: "company", Label: "Company"}},
+		Metadata:    map[string]any{"userID": "user_123"},
+	}, req)
+}
+
+func TestCheckoutBuilder_BuildWithErrors(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	field := CustomField{Type: "text", Key: "key", Label: "Label"}
+	req, err := NewCheckoutBuilder("").
+		CustomerID("cust_123").
+		CustomerEmail("user@example.com").
+		SuccessURL("example.com/return").
+		CustomField(field, field, field, field).
+		Metadata("fn", func() {}).
+		Build()
+
+	a.Nil(req)
+	a.Error(err)
+
+	var verr *ValidationError
+	a.True(errors.As(err, &verr))
+
+	fields := make([]string, 0, len(verr.Errors))
+	for _, fe := range verr.Errors {
+		fields = append(fields, fe.Field)
+	}
+	a.ElementsMatch([]string{
+		"product_id",
+		"customer",
+		"success_url",
+		"custom_field",
+		"custom_field[1].key",
+		"custom_field[2].key",
+		"custom_field[3].key",
+		"metadata.fn",
+	}, fields)
+}
+
+func TestCheckoutTemplate_Builder(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tmpl := &CheckoutTemplate{
+		ProductID:  "prod_123",
+		SuccessURL: "https://example.com/return",
+		Metadata:   map[string]any{"plan": "pro"},
+	}
+
+	first, err := tmpl.Builder().Metadata("userID", "user_1").Build()
+	a.NoError(err)
+	second, err := tmpl.Builder().Metadata("userID", "user_2").Build()
+	a.NoError(err)
+
+	a.Equal("user_1", first.Metadata["userID"])
+	a.Equal("user_2", second.Metadata["userID"])
+	a.Equal(map[string]any{"plan": "pro"}, tmpl.Metadata)
+	a.Equal(tmpl.ProductID, second.ProductID)
+}
+
+func TestCheckoutBuilder_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandlePostCheckout))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	req, err := NewCheckoutBuilder("prod_987654321").Build()
+	a.NoError(err)
+
+	resp, res, err := c.Checkouts.Create(context.Background(), req)
+
+	a.NoError(err)
+	a.NotNil(resp)
+	a.Equal(http.StatusOK, res.Status)
+}
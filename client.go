@@ -1,6 +1,11 @@
 package creemio
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
 )
 
@@ -14,6 +19,23 @@ type Client struct {
 	httpClient *http.Client
 	baseURL    string
 	apiKey     string
+	// keyProvider, if set, takes precedence over apiKey.
+	keyProvider KeyProvider
+
+	// mode is the environment the client is expected to talk to.
+	mode            Mode
+	strictMode      bool
+	productionGuard bool
+	dryRun          bool
+	dryRunLogger    *slog.Logger
+
+	auditSink           AuditSink
+	auditRedactedFields []string
+	auditErrorHandler   func(*AuditEntry, error)
+
+	cache *responseCache
+	// flights is nil when request coalescing is disabled.
+	flights *flightGroup
 
 	Checkouts     *CheckoutService
 	Customers     *CustomerService
@@ -23,20 +45,37 @@ type Client struct {
 	Discounts     *DiscountService
 	Licenses      *LicenseService
 	Stats         *StatsService
+	Refunds       *RefundService
 }
 
 type Option func(*Client)
 
+// New creates a client. If no base URL is given it is inferred from the API
+// key, test keys talk to TestAPIURL and everything else to APIURL.
 func New(opts ...Option) *Client {
 	c := &Client{
-		baseURL:    APIURL,
-		httpClient: http.DefaultClient,
+		httpClient:          http.DefaultClient,
+		auditRedactedFields: DefaultAuditRedactedFields,
+		auditErrorHandler:   defaultAuditErrorHandler,
+		flights:             &flightGroup{calls: make(map[string]*flightCall)},
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	key := c.apiKey
+	if c.keyProvider != nil {
+		// Only used to detect the environment, errors surface on the first request.
+		key, _ = c.keyProvider.APIKey(context.Background())
+	}
+	if len(c.baseURL) == 0 {
+		c.baseURL = baseURLForKey(key)
+	}
+	if len(c.mode) == 0 {
+		c.mode = detectMode(c.baseURL, key)
+	}
+
 	c.Checkouts = &CheckoutService{client: c}
 	c.Customers = &CustomerService{client: c}
 	c.Subscriptions = &SubscriptionService{client: c}
@@ -45,6 +84,160 @@ func New(opts ...Option) *Client {
 	c.Discounts = &DiscountService{client: c}
 	c.Licenses = &LicenseService{client: c}
 	c.Stats = &StatsService{client: c}
+	c.Refunds = &RefundService{client: c}
 
 	return c
 }
+
+// Mode returns the environment the client is expected to talk to, or an
+// empty Mode if it could not be determined.
+func (c *Client) Mode() Mode {
+	return c.mode
+}
+
+// mutation describes a call that changes billing state.
+type mutation struct {
+	service string
+	action  string
+	// resourceID is empty for calls creating a new object.
+	resourceID string
+	// guarded marks calls that change existing objects, these are refused
+	// by the production guard.
+	guarded bool
+	// simulate returns the object the call would respond with, used in
+	// dry-run mode.
+	simulate func(ctx context.Context) (any, error)
+}
+
+// do sends the request. Every service call goes through here, concurrent
+// identical GET requests share one round trip.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	return c.roundTrip(req, req.Method == http.MethodGet)
+}
+
+// doIdempotent sends a request without side effects that is not a GET, such
+// as license validation, coalescing it like a GET request.
+func (c *Client) doIdempotent(req *http.Request) (*http.Response, error) {
+	return c.roundTrip(req, true)
+}
+
+func (c *Client) roundTrip(req *http.Request, idempotent bool) (*http.Response, error) {
+	cached, cacheKey, ok := c.cachedResponse(req)
+	if ok {
+		return cached, nil
+	}
+
+	var res *http.Response
+	var err error
+	if idempotent && c.flights != nil {
+		res, err = c.coalesce(req)
+	} else {
+		res, err = c.send(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if c.strictMode && res.StatusCode < 400 {
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		res.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err := c.checkMode(body); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cacheKey) > 0 {
+		if err := c.storeResponse(req, res, cacheKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// doMutation sends a request that changes billing state and records it in
+// the audit log if one is configured.
+func (c *Client) doMutation(req *http.Request, m mutation) (*http.Response, error) {
+	res, err := c.sendMutation(req, m)
+	if c.auditSink != nil {
+		c.audit(req, m, res, err)
+	}
+	return res, err
+}
+
+func (c *Client) sendMutation(req *http.Request, m mutation) (*http.Response, error) {
+	// Nothing is sent in dry-run mode, so the production guard does not apply.
+	if c.dryRun {
+		return c.simulate(req, m)
+	}
+	if err := c.checkProductionGuard(req.Context(), m); err != nil {
+		return nil, err
+	}
+	if c.cache != nil {
+		// Invalidate even on failure, the call may have reached the server.
+		defer c.cache.invalidate(m.service)
+	}
+	return c.do(req)
+}
+
+// send performs the request, setting the API key from the key provider if
+// one is configured. A 401 response triggers one refresh and retry.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.keyProvider == nil {
+		return c.httpClient.Do(req)
+	}
+
+	if err := c.setAPIKey(req); err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	// Hand back the original response if the request cannot be retried.
+	if err := c.keyProvider.Refresh(req.Context()); err != nil {
+		return res, nil
+	}
+	retry, err := rewindRequest(req)
+	if err != nil {
+		return res, nil
+	}
+	res.Body.Close()
+
+	if err := c.setAPIKey(retry); err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(retry)
+}
+
+func (c *Client) setAPIKey(req *http.Request) error {
+	key, err := c.keyProvider.APIKey(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-api-key", key)
+	return nil
+}
+
+// rewindRequest returns a copy of req that can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return retry, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("request body cannot be rewound")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry.Body = body
+	return retry, nil
+}
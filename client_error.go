@@ -3,14 +3,23 @@ package creemio
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var (
 	errRequiredMissingField        = errors.New("missing required fields")
 	errRequiredFieldProductID      = errors.New("product_id is required")
 	errRequiredFieldSubscriptionID = errors.New("subscription_id is required")
+	errRequiredFieldDiscountID     = errors.New("discount_id is required")
+	errRequiredFieldTransactionID  = errors.New("transaction_id is required")
+	errRequiredFieldInstanceID     = errors.New("instance_id is required")
 )
 
+// ErrProductionMutation is returned by the production guard for calls that
+// change existing billing state without AllowProductionMutations.
+var ErrProductionMutation = errors.New("mutating call refused against production")
+
 // API Error response from creemio
 type APIError struct {
 	TraceID string `json:"trace_id"`
@@ -31,3 +40,50 @@ func newAPIError(data []byte) error {
 func (e *APIError) Error() string {
 	return e.Err
 }
+
+// FieldError describes a single field that failed client-side validation.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError aggregates every field error found while validating a
+// request before it is sent to creemio.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) add(field, message string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Message: message})
+}
+
+// err returns nil if no field errors were collected.
+func (e *ValidationError) err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// ModeMismatchError is returned in strict mode when the API responds with an
+// object from a different environment than the client expects.
+type ModeMismatchError struct {
+	Expected Mode
+	Got      Mode
+}
+
+func (e *ModeMismatchError) Error() string {
+	return fmt.Sprintf("expected %s mode response, got %s", e.Expected, e.Got)
+}
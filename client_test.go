@@ -1,10 +1,15 @@
 package creemio
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/evolvedevlab/creemio-go/mock"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -78,3 +83,111 @@ func TestNewClient_CombinesOptions(t *testing.T) {
 	a.Equal(customURL, c.baseURL)
 	a.Equal(customHTTP, c.httpClient)
 }
+
+func TestNewClient_DetectsEnvironment(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		baseURL string
+		mode    Mode
+	}{
+		{
+			name:    "test key",
+			opts:    []Option{WithAPIKey("creem_test_123")},
+			baseURL: TestAPIURL,
+			mode:    ModeTest,
+		},
+		{
+			name:    "live key",
+			opts:    []Option{WithAPIKey("creem_123")},
+			baseURL: APIURL,
+			mode:    ModeProduction,
+		},
+		{
+			name:    "explicit base url wins over key",
+			opts:    []Option{WithAPIKey("creem_test_123"), WithBaseURL(APIURL)},
+			baseURL: APIURL,
+			mode:    ModeProduction,
+		},
+		{
+			name:    "custom base url falls back to key",
+			opts:    []Option{WithAPIKey("creem_test_123"), WithBaseURL("https://custom.api")},
+			baseURL: "https://custom.api",
+			mode:    ModeTest,
+		},
+		{
+			name:    "explicit mode",
+			opts:    []Option{WithBaseURL("https://custom.api"), WithMode(ModeSandbox)},
+			baseURL: "https://custom.api",
+			mode:    ModeSandbox,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(tt.opts...)
+			assert.Equal(t, tt.baseURL, c.baseURL)
+			assert.Equal(t, tt.mode, c.Mode())
+		})
+	}
+}
+
+func TestNewClient_WithStrictMode(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandleGetSubscription))
+	defer s.Close()
+
+	// The mock responds with test mode objects.
+	c := New(
+		WithBaseURL(s.URL),
+		WithMode(ModeProduction),
+		WithStrictMode(),
+	)
+
+	resp, _, err := c.Subscriptions.Get(context.Background(), "sub_123")
+
+	var modeErr *ModeMismatchError
+	a.True(errors.As(err, &modeErr))
+	a.Equal(ModeProduction, modeErr.Expected)
+	a.Equal(ModeTest, modeErr.Got)
+	a.Nil(resp)
+
+	c = New(
+		WithBaseURL(s.URL),
+		WithMode(ModeTest),
+		WithStrictMode(),
+	)
+
+	resp, _, err = c.Subscriptions.Get(context.Background(), "sub_123")
+
+	a.NoError(err)
+	a.NotNil(resp)
+}
+
+func TestNewClient_WithProductionGuard(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandlePostCancelSubscription))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithMode(ModeProduction),
+		WithProductionGuard(),
+	)
+
+	resp, res, err := c.Subscriptions.Cancel(context.Background(), "sub_123")
+
+	a.ErrorIs(err, ErrProductionMutation)
+	a.Nil(resp)
+	a.Nil(res)
+
+	resp, res, err = c.Subscriptions.Cancel(AllowProductionMutations(context.Background()), "sub_123")
+
+	a.NoError(err)
+	a.NotNil(resp)
+	a.Equal(http.StatusOK, res.Status)
+}
@@ -0,0 +1,85 @@
+// Command creem-exporter serves Creem business metrics to Prometheus.
+//
+// The API key is read from the API_KEY environment variable.
+//
+//	API_KEY=creem_xxx creem-exporter -listen :9464 -currencies EUR,USD
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go"
+	"github.com/evolvedevlab/creemio-go/exporter"
+)
+
+func main() {
+	listen := flag.String("listen", ":9464", "address to serve /metrics on")
+	interval := flag.Duration("interval", exporter.DefaultPollInterval, "time between two polls of the stats endpoint")
+	currencies := flag.String("currencies", "EUR,USD", "comma-separated currencies to poll")
+	period := flag.String("period", string(creemio.IntervalDay), "bucket size of the latest period: day, week or month")
+	baseURL := flag.String("base-url", "", "API base URL, inferred from the API key when empty")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	requests := &exporter.RequestCounter{}
+	opts := []creemio.Option{
+		creemio.WithKeyProvider(creemio.EnvKeyProvider("API_KEY")),
+		creemio.WithHTTPClient(&http.Client{
+			Timeout:   30 * time.Second,
+			Transport: requests.Transport(nil),
+		}),
+	}
+	if len(*baseURL) > 0 {
+		opts = append(opts, creemio.WithBaseURL(*baseURL))
+	}
+
+	e := exporter.New(exporter.Config{
+		Client:         creemio.New(opts...),
+		Currencies:     parseCurrencies(*currencies),
+		PollInterval:   *interval,
+		PeriodInterval: creemio.Interval(*period),
+		Requests:       requests,
+		Logger:         logger,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", e)
+	srv := &http.Server{Addr: *listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go e.Run(ctx)
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	logger.Info("serving metrics", "addr", *listen)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("server failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func parseCurrencies(s string) []creemio.Currency {
+	var currencies []creemio.Currency
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.TrimSpace(c); len(c) > 0 {
+			currencies = append(currencies, creemio.Currency(strings.ToUpper(c)))
+		}
+	}
+	return currencies
+}
@@ -0,0 +1,190 @@
+// Command creemio runs administrative tasks against the Creem API.
+//
+// The API key is read from the API_KEY environment variable.
+//
+//	creemio gdpr export -email user@example.com -out user.zip
+//	creemio gdpr erase -email user@example.com [-confirm]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/evolvedevlab/creemio-go"
+	"github.com/evolvedevlab/creemio-go/gdpr"
+)
+
+const usage = `usage:
+  creemio gdpr export -email EMAIL [-out FILE] [-checkout ID]... [-license KEY:INSTANCE]...
+  creemio gdpr erase -email EMAIL [-confirm] [-checkout ID]... [-license KEY:INSTANCE]...
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "creemio:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) < 2 || args[0] != "gdpr" {
+		fmt.Fprint(os.Stderr, usage)
+		return errors.New("unknown command")
+	}
+
+	switch args[1] {
+	case "export":
+		return gdprExport(ctx, args[2:])
+	case "erase":
+		return gdprErase(ctx, args[2:])
+	}
+	fmt.Fprint(os.Stderr, usage)
+	return fmt.Errorf("unknown gdpr command %q", args[1])
+}
+
+// listFlag collects a repeated flag.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// licenseFlag collects a repeated KEY:INSTANCE flag, as validating a license
+// needs one of its instances.
+type licenseFlag []creemio.LicenseValidateRequest
+
+func (l *licenseFlag) String() string {
+	pairs := make([]string, len(*l))
+	for i, license := range *l {
+		pairs[i] = license.Key + ":" + license.InstanceID
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (l *licenseFlag) Set(v string) error {
+	key, instance, ok := strings.Cut(v, ":")
+	if !ok || len(key) == 0 || len(instance) == 0 {
+		return errors.New("want KEY:INSTANCE")
+	}
+	*l = append(*l, creemio.LicenseValidateRequest{Key: key, InstanceID: instance})
+	return nil
+}
+
+type gdprFlags struct {
+	*flag.FlagSet
+	email     string
+	baseURL   string
+	checkouts listFlag
+	licenses  licenseFlag
+}
+
+func newGDPRFlags(name string) *gdprFlags {
+	f := &gdprFlags{FlagSet: flag.NewFlagSet(name, flag.ExitOnError)}
+	f.StringVar(&f.email, "email", "", "email of the customer")
+	f.StringVar(&f.baseURL, "base-url", "", "API base URL, inferred from the API key when empty")
+	f.Var(&f.checkouts, "checkout", "ID of a checkout of the customer, may be repeated")
+	f.Var(&f.licenses, "license", "license key of the customer and the ID of one of its instances, as KEY:INSTANCE, may be repeated")
+	return f
+}
+
+func (f *gdprFlags) options() *creemio.CustomerOverviewOptions {
+	return &creemio.CustomerOverviewOptions{
+		CheckoutIDs: f.checkouts,
+		Licenses:    f.licenses,
+	}
+}
+
+func newClient(baseURL string, opts ...creemio.Option) *creemio.Client {
+	opts = append(opts, creemio.WithKeyProvider(creemio.EnvKeyProvider("API_KEY")))
+	if len(baseURL) > 0 {
+		opts = append(opts, creemio.WithBaseURL(baseURL))
+	}
+	return creemio.New(opts...)
+}
+
+func gdprExport(ctx context.Context, args []string) error {
+	f := newGDPRFlags("gdpr export")
+	out := f.String("out", "", "archive to write, customer-<id>.zip when empty")
+	f.Parse(args)
+
+	export, err := gdpr.Collect(ctx, newClient(f.baseURL), f.email, f.options())
+	if err != nil {
+		return err
+	}
+
+	path := *out
+	if len(path) == 0 {
+		path = fmt.Sprintf("customer-%s.zip", export.Overview.Customer.ID)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := export.WriteZip(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Println("wrote", path)
+	printChecklist(export.Checklist, "done")
+	return nil
+}
+
+func gdprErase(ctx context.Context, args []string) error {
+	f := newGDPRFlags("gdpr erase")
+	confirm := f.Bool("confirm", false, "carry out the API steps instead of a dry run")
+	f.Parse(args)
+
+	var opts []creemio.Option
+	if !*confirm {
+		opts = append(opts, creemio.WithDryRun(slog.New(slog.NewTextHandler(os.Stderr, nil))))
+	}
+	client := newClient(f.baseURL, opts...)
+
+	export, err := gdpr.Collect(ctx, client, f.email, f.options())
+	if err != nil {
+		return err
+	}
+
+	err = gdpr.Erase(ctx, client, export.Checklist)
+	if *confirm {
+		printChecklist(export.Checklist, "done")
+	} else {
+		printChecklist(export.Checklist, "dry run")
+		fmt.Println("rerun with -confirm to carry out the API steps")
+	}
+	return err
+}
+
+// printChecklist prints the items, done ones are labelled with done.
+func printChecklist(items []gdpr.Item, done string) {
+	for _, item := range items {
+		state := "todo"
+		switch {
+		case item.Done:
+			state = done
+		case len(item.Error) > 0:
+			state = "failed: " + item.Error
+		case item.Via == gdpr.ViaRetain:
+			state = "retain"
+		}
+		fmt.Printf("[%s] %-9s %-12s %s %s\n", state, item.Via, item.Object, item.ID, item.Action)
+	}
+}
@@ -0,0 +1,126 @@
+package creemio
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// flightGroup shares one in-flight request between concurrent identical
+// calls.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	// waiters is the number of callers still waiting, the request is
+	// canceled once all of them have given up.
+	waiters int
+	cancel  context.CancelFunc
+
+	res  *http.Response
+	body []byte
+	err  error
+}
+
+// keyFingerprint identifies the API key used for a request without exposing
+// it.
+func (c *Client) keyFingerprint(ctx context.Context) string {
+	key := c.apiKey
+	if c.keyProvider != nil {
+		key, _ = c.keyProvider.APIKey(ctx)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (c *Client) flightKey(req *http.Request) (string, bool) {
+	key := req.Method + "|" + c.keyFingerprint(req.Context()) + "|" + req.URL.String()
+	if req.Body == nil || req.Body == http.NoBody {
+		return key, true
+	}
+	if req.GetBody == nil {
+		return "", false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", false
+	}
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		return "", false
+	}
+	return key + "|" + string(payload), true
+}
+
+// coalesce sends req, sharing the response with concurrent callers sending
+// an identical request. Each caller gets its own copy of the response and
+// returns early if its context is done.
+func (c *Client) coalesce(req *http.Request) (*http.Response, error) {
+	key, ok := c.flightKey(req)
+	if !ok {
+		return c.send(req)
+	}
+
+	g := c.flights
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if ok {
+		call.waiters++
+	} else {
+		// The shared request outlives the caller that started it.
+		ctx, cancel := context.WithCancel(context.WithoutCancel(req.Context()))
+		call = &flightCall{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		g.calls[key] = call
+		go c.runFlight(key, call, req.WithContext(ctx))
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-req.Context().Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Later callers must not join a canceled request.
+			delete(g.calls, key)
+			call.cancel()
+		}
+		g.mu.Unlock()
+		return nil, req.Context().Err()
+	}
+
+	if call.err != nil {
+		return nil, call.err
+	}
+	res := *call.res
+	res.Header = call.res.Header.Clone()
+	res.Body = io.NopCloser(bytes.NewReader(call.body))
+	res.Request = req
+	return &res, nil
+}
+
+func (c *Client) runFlight(key string, call *flightCall, req *http.Request) {
+	defer call.cancel()
+
+	res, err := c.send(req)
+	if err == nil {
+		call.body, err = io.ReadAll(res.Body)
+		res.Body.Close()
+	}
+	call.res, call.err = res, err
+
+	g := c.flights
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	close(call.done)
+}
@@ -0,0 +1,128 @@
+package creemio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newGatedServer serves handler once release is closed, counting requests.
+func newGatedServer(handler http.HandlerFunc, hits *atomic.Int32, release <-chan struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		handler(w, r)
+	}))
+}
+
+func TestCoalesce_SharesInFlightRequest(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var hits atomic.Int32
+	release := make(chan struct{})
+	s := newGatedServer(mock.HandlePostValidateLicense, &hits, release)
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+	data := &LicenseValidateRequest{Key: "ABC123", InstanceID: "inst_1"}
+
+	const callers = 20
+	var wg sync.WaitGroup
+	licenses := make([]*License, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			licenses[i], _, errs[i] = c.Licenses.Validate(context.Background(), data)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	a.EqualValues(1, hits.Load())
+	for i := range callers {
+		a.NoError(errs[i])
+		a.Equal(licenses[0], licenses[i])
+	}
+
+	// A different body is a different call.
+	_, _, err := c.Licenses.Validate(context.Background(), &LicenseValidateRequest{Key: "XYZ789"})
+	a.NoError(err)
+	a.EqualValues(2, hits.Load())
+}
+
+func TestCoalesce_HonorsCallerContext(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var hits atomic.Int32
+	release := make(chan struct{})
+	s := newGatedServer(mock.HandleGetProduct, &hits, release)
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, _, err := c.Products.Get(ctx, "prod_123")
+		canceled <- err
+	}()
+
+	done := make(chan error)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_, _, err := c.Products.Get(context.Background(), "prod_123")
+		done <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	a.ErrorIs(<-canceled, context.Canceled)
+
+	// The other caller still gets the shared result.
+	close(release)
+	a.NoError(<-done)
+	a.EqualValues(1, hits.Load())
+}
+
+func TestCoalesce_Disabled(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var hits atomic.Int32
+	release := make(chan struct{})
+	s := newGatedServer(mock.HandleGetSubscription, &hits, release)
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithoutRequestCoalescing(),
+	)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := c.Subscriptions.Get(context.Background(), "sub_123")
+			a.NoError(err)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	a.EqualValues(3, hits.Load())
+}
@@ -23,16 +23,3 @@ type Pagination struct {
 	NextPage     int  `json:"next_page"`
 	PreviousPage *int `json:"prev_page"`
 }
-
-type CustomField struct {
-	Type     string    `json:"type"`
-	Key      string    `json:"key"`
-	Label    string    `json:"label"`
-	Optional bool      `json:"optional"`
-	Text     *TextSpec `json:"text,omitempty"`
-}
-
-type TextSpec struct {
-	MaxLength int `json:"max_length"`
-	MinLength int `json:"min_length"`
-}
@@ -0,0 +1,206 @@
+package creemio
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	MaxCustomFieldKeyLength   = 200
+	MaxCustomFieldLabelLength = 50
+	MaxCustomFieldTextLength  = 255
+)
+
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText     CustomFieldType = "text"
+	CustomFieldTypeCheckbox CustomFieldType = "checkbox"
+)
+
+type CustomField struct {
+	Type     CustomFieldType `json:"type"`
+	Key      string          `json:"key"`
+	Label    string          `json:"label"`
+	Optional bool            `json:"optional"`
+	Text     *TextSpec       `json:"text,omitempty"`
+	Checkbox *CheckboxSpec   `json:"checkbox,omitempty"`
+}
+
+// TextSpec configures a text field. Value holds the buyer's input once the
+// checkout is completed.
+type TextSpec struct {
+	MaxLength int    `json:"max_length"`
+	MinLength int    `json:"min_length"`
+	Value     string `json:"value,omitempty"`
+}
+
+// CheckboxSpec configures a checkbox field. Label is rendered next to the
+// checkbox and supports markdown links. Value holds whether the buyer ticked
+// the box once the checkout is completed.
+type CheckboxSpec struct {
+	Label string `json:"label,omitempty"`
+	Value *bool  `json:"value,omitempty"`
+}
+
+// TextField returns a required text field. Zero lengths are left to the API defaults.
+func TextField(key, label string, minLength, maxLength int) CustomField {
+	return CustomField{
+		Type:  CustomFieldTypeText,
+		Key:   key,
+		Label: label,
+		Text: &TextSpec{
+			MinLength: minLength,
+			MaxLength: maxLength,
+		},
+	}
+}
+
+// CheckboxField returns a required checkbox field, text is shown next to the box.
+func CheckboxField(key, label, text string) CustomField {
+	return CustomField{
+		Type:     CustomFieldTypeCheckbox,
+		Key:      key,
+		Label:    label,
+		Checkbox: &CheckboxSpec{Label: text},
+	}
+}
+
+// Validate checks the key, label and spec constraints of the field.
+func (f *CustomField) Validate() error {
+	verr := &ValidationError{}
+	f.validate("", verr)
+	return verr.err()
+}
+
+func (f *CustomField) validate(prefix string, verr *ValidationError) {
+	switch {
+	case len(f.Key) == 0:
+		verr.add(prefix+"key", "is required")
+	case len(f.Key) > MaxCustomFieldKeyLength:
+		verr.add(prefix+"key", fmt.Sprintf("must be at most %d characters", MaxCustomFieldKeyLength))
+	case !isCustomFieldKey(f.Key):
+		verr.add(prefix+"key", "must only contain letters, digits and underscores")
+	}
+
+	switch {
+	case len(f.Label) == 0:
+		verr.add(prefix+"label", "is required")
+	case utf8.RuneCountInString(f.Label) > MaxCustomFieldLabelLength:
+		verr.add(prefix+"label", fmt.Sprintf("must be at most %d characters", MaxCustomFieldLabelLength))
+	}
+
+	switch f.Type {
+	case CustomFieldTypeText:
+		if f.Checkbox != nil {
+			verr.add(prefix+"checkbox", "must not be set on a text field")
+		}
+		if t := f.Text; t != nil {
+			if t.MinLength < 0 || t.MaxLength < 0 {
+				verr.add(prefix+"text", "lengths must not be negative")
+			}
+			if t.MaxLength > MaxCustomFieldTextLength {
+				verr.add(prefix+"text.max_length", fmt.Sprintf("must be at most %d", MaxCustomFieldTextLength))
+			}
+			if t.MaxLength > 0 && t.MinLength > t.MaxLength {
+				verr.add(prefix+"text.min_length", "must not be greater than max_length")
+			}
+		}
+	case CustomFieldTypeCheckbox:
+		if f.Text != nil {
+			verr.add(prefix+"text", "must not be set on a checkbox field")
+		}
+	case "":
+		verr.add(prefix+"type", "is required")
+	default:
+		verr.add(prefix+"type", fmt.Sprintf("unknown type %q", f.Type))
+	}
+}
+
+func isCustomFieldKey(key string) bool {
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// Value returns the submitted value as a typed Go value: string for text
+// fields and bool for checkbox fields. ok is false if nothing was submitted.
+func (f *CustomField) Value() (value any, ok bool) {
+	switch f.Type {
+	case CustomFieldTypeText:
+		if f.Text != nil && len(f.Text.Value) > 0 {
+			return f.Text.Value, true
+		}
+	case CustomFieldTypeCheckbox:
+		if f.Checkbox != nil && f.Checkbox.Value != nil {
+			return *f.Checkbox.Value, true
+		}
+	}
+	return nil, false
+}
+
+// LookupCustomField returns the custom field with the given key.
+func (c *Checkout) LookupCustomField(key string) (*CustomField, bool) {
+	for i := range c.CustomFields {
+		if c.CustomFields[i].Key == key {
+			return &c.CustomFields[i], true
+		}
+	}
+	return nil, false
+}
+
+// CustomFieldText returns the text submitted for the text field key.
+func (c *Checkout) CustomFieldText(key string) (string, bool) {
+	f, ok := c.LookupCustomField(key)
+	if !ok || f.Type != CustomFieldTypeText {
+		return "", false
+	}
+	v, ok := f.Value()
+	if !ok {
+		return "", false
+	}
+	return v.(string), true
+}
+
+// CustomFieldCheckbox returns whether the checkbox field key was ticked.
+func (c *Checkout) CustomFieldCheckbox(key string) (bool, bool) {
+	f, ok := c.LookupCustomField(key)
+	if !ok || f.Type != CustomFieldTypeCheckbox {
+		return false, false
+	}
+	v, ok := f.Value()
+	if !ok {
+		return false, false
+	}
+	return v.(bool), true
+}
+
+// CustomFieldValues returns all submitted values keyed by field key.
+func (c *Checkout) CustomFieldValues() map[string]any {
+	values := make(map[string]any, len(c.CustomFields))
+	for i := range c.CustomFields {
+		if v, ok := c.CustomFields[i].Value(); ok {
+			values[c.CustomFields[i].Key] = v
+		}
+	}
+	return values
+}
+
+func (w *WebHookCheckoutRequest) LookupCustomField(key string) (*CustomField, bool) {
+	return w.CheckoutObject.LookupCustomField(key)
+}
+
+func (w *WebHookCheckoutRequest) CustomFieldText(key string) (string, bool) {
+	return w.CheckoutObject.CustomFieldText(key)
+}
+
+func (w *WebHookCheckoutRequest) CustomFieldCheckbox(key string) (bool, bool) {
+	return w.CheckoutObject.CustomFieldCheckbox(key)
+}
+
+func (w *WebHookCheckoutRequest) CustomFieldValues() map[string]any {
+	return w.CheckoutObject.CustomFieldValues()
+}
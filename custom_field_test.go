@@ -0,0 +1,143 @@
+package creemio
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomField_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  CustomField
+		fields []string
+	}{
+		{
+			name:  "valid text field",
+			field: TextField("company", "Company", 1, 100),
+		},
+		{
+			name:  "valid checkbox field",
+			field: CheckboxField("terms", "Terms", "I accept the [terms](https://example.com/terms)"),
+		},
+		{
+			name:   "missing key and label",
+			field:  CustomField{Type: CustomFieldTypeText},
+			fields: []string{"key", "label"},
+		},
+		{
+			name:   "invalid key characters",
+			field:  TextField("company-name", "Company", 0, 0),
+			fields: []string{"key"},
+		},
+		{
+			name:   "label too long",
+			field:  TextField("company", strings.Repeat("a", MaxCustomFieldLabelLength+1), 0, 0),
+			fields: []string{"label"},
+		},
+		{
+			name:   "min length greater than max length",
+			field:  TextField("company", "Company", 10, 5),
+			fields: []string{"text.min_length"},
+		},
+		{
+			name:   "unknown type",
+			field:  CustomField{Type: "dropdown", Key: "size", Label: "Size"},
+			fields: []string{"type"},
+		},
+		{
+			name: "text spec on checkbox",
+			field: CustomField{
+				Type:  CustomFieldTypeCheckbox,
+				Key:   "terms",
+				Label: "Terms",
+				Text:  &TextSpec{},
+			},
+			fields: []string{"text"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.field.Validate()
+			if len(tt.fields) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var verr *ValidationError
+			assert.True(t, errors.As(err, &verr))
+
+			fields := make([]string, 0, len(verr.Errors))
+			for _, fe := range verr.Errors {
+				fields = append(fields, fe.Field)
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestCheckout_CustomFieldValues(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	data := []byte(`{
+  "id": "evt_1",
+  "eventType": "checkout.completed",
+  "object": {
+    "id": "ch_1",
+    "custom_fields": [
+      {
+        "type": "text",
+        "key": "company",
+        "label": "Company",
+        "optional": false,
+        "text": {"max_length": 100, "min_length": 1, "value": "Acme"}
+      },
+      {
+        "type": "checkbox",
+        "key": "terms",
+        "label": "Terms",
+        "optional": false,
+        "checkbox": {"label": "I accept", "value": true}
+      },
+      {
+        "type": "text",
+        "key": "notes",
+        "label": "Notes",
+        "optional": true,
+        "text": {"max_length": 100, "min_length": 0}
+      }
+    ]
+  }
+}`)
+
+	var event WebHookCheckoutRequest
+	err := json.Unmarshal(data, &event)
+	a.NoError(err)
+
+	company, ok := event.CustomFieldText("company")
+	a.True(ok)
+	a.Equal("Acme", company)
+
+	terms, ok := event.CustomFieldCheckbox("terms")
+	a.True(ok)
+	a.True(terms)
+
+	_, ok = event.CustomFieldText("notes")
+	a.False(ok)
+
+	_, ok = event.CustomFieldCheckbox("company")
+	a.False(ok)
+
+	_, ok = event.LookupCustomField("missing")
+	a.False(ok)
+
+	a.Equal(map[string]any{
+		"company": "Acme",
+		"terms":   true,
+	}, event.CustomFieldValues())
+}
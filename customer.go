@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"iter"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -59,6 +62,77 @@ type CustomerList struct {
 type CustomerListQuery struct {
 	PageNumber int
 	PageSize   int
+
+	// The fields below are filtered client-side, as in
+	// TransactionListQuery.
+
+	// Email matches customers whose email contains it, case-insensitively.
+	Email         string
+	Country       string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// matches reports whether c passes the client-side filters of q.
+func (q *CustomerListQuery) matches(c *Customer) bool {
+	if len(q.Email) > 0 && !strings.Contains(strings.ToLower(c.Email), strings.ToLower(q.Email)) {
+		return false
+	}
+	if len(q.Country) > 0 && !strings.EqualFold(c.Country, q.Country) {
+		return false
+	}
+	if !q.CreatedAfter.IsZero() && c.CreatedAt.Before(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !c.CreatedAt.Before(q.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+type CreateCustomerRequest struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+	// Country is an ISO 3166-1 alpha-2 code, such as "US".
+	Country string `json:"country,omitempty"`
+}
+
+// Validate checks that the email is present and the country is a known
+// ISO 3166-1 alpha-2 code.
+func (r *CreateCustomerRequest) Validate() error {
+	verr := &ValidationError{}
+	if len(r.Email) == 0 {
+		verr.add("email", "is required")
+	}
+	validateCountry(r.Country, verr)
+	return verr.err()
+}
+
+type UpdateCustomerRequest struct {
+	CustomerID string `json:"-"`
+	Name       string `json:"name,omitempty"`
+	// Country is an ISO 3166-1 alpha-2 code, such as "US".
+	Country string `json:"country,omitempty"`
+}
+
+// Validate checks that the customer ID and a field to change are present
+// and the country is a known ISO 3166-1 alpha-2 code.
+func (r *UpdateCustomerRequest) Validate() error {
+	verr := &ValidationError{}
+	if len(r.CustomerID) == 0 {
+		verr.add("customer_id", "is required")
+	}
+	if len(r.Name) == 0 && len(r.Country) == 0 {
+		verr.add("name", "either name or country must be present")
+	}
+	validateCountry(r.Country, verr)
+	return verr.err()
+}
+
+func validateCountry(country string, verr *ValidationError) {
+	if len(country) > 0 && !IsCountryCode(country) {
+		verr.add("country", "must be an upper-case ISO 3166-1 alpha-2 code")
+	}
 }
 
 type CustomerService struct {
@@ -87,9 +161,9 @@ func (s *CustomerService) Get(ctx context.Context, query *CustomerRequestQuery)
 	}
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
-		return nil, newResponse(res, nil), err
+		return nil, nil, err
 	}
 	defer res.Body.Close()
 
@@ -130,7 +204,7 @@ func (s *CustomerService) List(ctx context.Context, query *CustomerListQuery) (*
 		req.URL.RawQuery = q.Encode()
 	}
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -148,10 +222,139 @@ func (s *CustomerService) List(ctx context.Context, query *CustomerListQuery) (*
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, newResponse(res, body), err
 	}
+	if query != nil {
+		result.Items = slices.DeleteFunc(result.Items, func(c Customer) bool {
+			return !query.matches(&c)
+		})
+	}
 
 	return &result, newResponse(res, body), nil
 }
 
+// All iterates over the customers matching query on every page, starting at
+// query.PageNumber. Iteration stops at the first error.
+func (s *CustomerService) All(ctx context.Context, query *CustomerListQuery) iter.Seq2[Customer, error] {
+	q := CustomerListQuery{}
+	if query != nil {
+		q = *query
+	}
+	return paginate(q.PageNumber, func(page int) ([]Customer, Pagination, error) {
+		q.PageNumber = page
+		list, _, err := s.List(ctx, &q)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+}
+
+// Create creates a customer, e.g. to pass its ID to a checkout. The request
+// is validated before it is sent.
+func (s *CustomerService) Create(ctx context.Context, data *CreateCustomerRequest) (*Customer, *Response, error) {
+	if data == nil {
+		return nil, nil, errRequiredMissingField
+	}
+	if err := data.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	targetUrl := makeUrl(s.client.baseURL, "/customers")
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetUrl, bytes.NewReader(payload))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", s.client.apiKey)
+
+	res, err := s.client.doMutation(req, mutation{
+		service: "customers",
+		action:  "create",
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateCreate(data), nil
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, newResponse(res, body), err
+	}
+	if res.StatusCode >= 400 {
+		return nil, newResponse(res, body), newAPIError(body)
+	}
+
+	var customer Customer
+	if err := json.Unmarshal(body, &customer); err != nil {
+		return nil, newResponse(res, body), err
+	}
+
+	return &customer, newResponse(res, body), nil
+}
+
+// Update changes the name or country of a customer. Empty fields are left
+// unchanged, so a name or country cannot be cleared. The request is
+// validated before it is sent.
+func (s *CustomerService) Update(ctx context.Context, data *UpdateCustomerRequest) (*Customer, *Response, error) {
+	if data == nil {
+		return nil, nil, errRequiredMissingField
+	}
+	if err := data.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	targetUrl := makeUrl(s.client.baseURL, "/customers", data.CustomerID)
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetUrl, bytes.NewReader(payload))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", s.client.apiKey)
+
+	res, err := s.client.doMutation(req, mutation{
+		service:    "customers",
+		action:     "update",
+		resourceID: data.CustomerID,
+		guarded:    true,
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateUpdate(ctx, data)
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, newResponse(res, body), err
+	}
+	if res.StatusCode >= 400 {
+		return nil, newResponse(res, body), newAPIError(body)
+	}
+
+	var customer Customer
+	if err := json.Unmarshal(body, &customer); err != nil {
+		return nil, newResponse(res, body), err
+	}
+
+	return &customer, newResponse(res, body), nil
+}
+
 func (s *CustomerService) GetBillingPortalURL(ctx context.Context, customerID string) (string, *Response, error) {
 	targetUrl := makeUrl(s.client.baseURL, "/customers", "billing")
 
@@ -168,7 +371,7 @@ func (s *CustomerService) GetBillingPortalURL(ctx context.Context, customerID st
 	req.Header.Set("x-api-key", s.client.apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return "", nil, err
 	}
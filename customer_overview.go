@@ -0,0 +1,188 @@
+package creemio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// CustomerOverviewOptions lists the objects the API cannot look up by
+// customer, e.g. IDs and keys stored from webhooks.
+type CustomerOverviewOptions struct {
+	CheckoutIDs []string
+	// Licenses are validated, which needs the ID of an instance along with
+	// each key.
+	Licenses []LicenseValidateRequest
+	// Batch paces the subscription, checkout and license requests.
+	Batch *BatchOptions
+}
+
+// CustomerOverview is everything known about a customer.
+type CustomerOverview struct {
+	Customer      *Customer
+	Transactions  []Transaction
+	Subscriptions []Subscription
+	// Checkouts and Licenses are nil unless CheckoutsResolved and
+	// LicensesResolved are set. The API cannot look them up by customer, so
+	// they are only resolved from the IDs and keys passed to
+	// OverviewWithOptions.
+	Checkouts         []Checkout
+	Licenses          []License
+	CheckoutsResolved bool
+	LicensesResolved  bool
+	// LifetimeValue is the amount paid minus refunds per upper-case
+	// currency, in the smallest currency unit.
+	LifetimeValue map[string]int
+	// CurrentPlan is the most recent subscription that is active,
+	// trialing or scheduled to cancel, nil without one.
+	CurrentPlan      *Subscription
+	BillingPortalURL string
+}
+
+// Overview resolves the customer by ID or email and gathers their
+// transactions, subscriptions and billing portal URL concurrently.
+// Subscriptions are those referenced by the transactions. Checkouts and
+// licenses cannot be looked up by customer and are left unresolved, use
+// OverviewWithOptions to pass the known ones. If only some parts fail, the
+// overview is returned along with their joined errors.
+func (s *CustomerService) Overview(ctx context.Context, query CustomerRequestQuery) (*CustomerOverview, error) {
+	return s.OverviewWithOptions(ctx, query, nil)
+}
+
+// OverviewWithOptions is Overview, additionally gathering the checkouts and
+// licenses listed in opts.
+func (s *CustomerService) OverviewWithOptions(ctx context.Context, query CustomerRequestQuery, opts *CustomerOverviewOptions) (*CustomerOverview, error) {
+	if opts == nil {
+		opts = &CustomerOverviewOptions{}
+	}
+
+	customer, _, err := s.Get(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &CustomerOverview{
+		Customer:      customer,
+		LifetimeValue: make(map[string]int),
+	}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	fail := func(part string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, fmt.Errorf("%s: %w", part, err))
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		var ids []string
+		for t, err := range s.client.Transactions.All(ctx, &TransactionListQuery{CustomerID: customer.ID}) {
+			if err != nil {
+				fail("transactions", err)
+				break
+			}
+			overview.Transactions = append(overview.Transactions, t)
+			if len(t.Subscription) > 0 && !slices.Contains(ids, t.Subscription) {
+				ids = append(ids, t.Subscription)
+			}
+		}
+
+		for _, r := range s.client.Subscriptions.GetMany(ctx, ids, opts.Batch) {
+			if r.Err != nil {
+				fail("subscription "+r.ID, r.Err)
+				continue
+			}
+			overview.Subscriptions = append(overview.Subscriptions, *r.Value)
+		}
+	}()
+
+	if len(opts.CheckoutIDs) > 0 {
+		overview.CheckoutsResolved = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range s.client.Checkouts.GetMany(ctx, opts.CheckoutIDs, opts.Batch) {
+				if r.Err != nil {
+					fail("checkout "+r.ID, r.Err)
+					continue
+				}
+				overview.Checkouts = append(overview.Checkouts, *r.Value)
+			}
+		}()
+	}
+
+	if len(opts.Licenses) > 0 {
+		overview.LicensesResolved = true
+		instances := make(map[string]string, len(opts.Licenses))
+		keys := make([]string, 0, len(opts.Licenses))
+		for _, l := range opts.Licenses {
+			if _, ok := instances[l.Key]; !ok {
+				keys = append(keys, l.Key)
+				instances[l.Key] = l.InstanceID
+			}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			validate := func(ctx context.Context, key string) (*License, *Response, error) {
+				if len(instances[key]) == 0 {
+					return nil, nil, errRequiredFieldInstanceID
+				}
+				return s.client.Licenses.Validate(ctx, &LicenseValidateRequest{Key: key, InstanceID: instances[key]})
+			}
+			for _, r := range getMany(ctx, keys, opts.Batch, validate) {
+				if r.Err != nil {
+					fail("license "+r.ID, r.Err)
+					continue
+				}
+				overview.Licenses = append(overview.Licenses, *r.Value)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		url, _, err := s.GetBillingPortalURL(ctx, customer.ID)
+		if err != nil {
+			fail("billing portal", err)
+			return
+		}
+		overview.BillingPortalURL = url
+	}()
+
+	wg.Wait()
+
+	for _, t := range overview.Transactions {
+		switch t.Status {
+		case TransactionStatusPaid, TransactionStatusRefunded, TransactionStatusPartialRefund:
+			overview.LifetimeValue[strings.ToUpper(t.Currency)] += t.AmountPaid - t.RefundedAmount
+		}
+	}
+	overview.CurrentPlan = currentPlan(overview.Subscriptions)
+
+	return overview, errors.Join(errs...)
+}
+
+func currentPlan(subscriptions []Subscription) *Subscription {
+	var current *Subscription
+	for i := range subscriptions {
+		sub := &subscriptions[i]
+		switch sub.Status {
+		case SubscriptionStatusActive, SubscriptionStatusTrialing, SubscriptionStatusScheduledCancel:
+			if current == nil || sub.CreatedAt.After(current.CreatedAt) {
+				current = sub
+			}
+		}
+	}
+	return current
+}
@@ -0,0 +1,114 @@
+package creemio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomers_Overview(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET /%s/customers", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("user@example.com", r.URL.Query().Get("email"))
+		mock.HandleGetCustomer(w, r)
+	})
+	mux.HandleFunc(fmt.Sprintf("POST /%s/customers/billing", APIVersion), mock.HandleGetBillingPortalURL)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/transactions/search", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("cus_abc123", r.URL.Query().Get("customer_id"))
+		json.NewEncoder(w).Encode(TransactionList{
+			Items: []Transaction{
+				{ID: "txn_1", Subscription: "sub_1", Currency: "usd", AmountPaid: 2000, Status: TransactionStatusPaid},
+				{ID: "txn_2", Subscription: "sub_1", Currency: "USD", AmountPaid: 2000, RefundedAmount: 500, Status: TransactionStatusPartialRefund},
+				{ID: "txn_3", Subscription: "sub_2", Currency: "EUR", AmountPaid: 900, Status: TransactionStatusPaid},
+				{ID: "txn_4", Currency: "EUR", AmountPaid: 900, Status: TransactionStatusDeclined},
+			},
+		})
+	})
+	mux.HandleFunc(fmt.Sprintf("GET /%s/subscriptions", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("subscription_id")
+		sub := Subscription{ID: id, Status: SubscriptionStatusActive, CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+		if id == "sub_2" {
+			sub.CreatedAt = sub.CreatedAt.AddDate(0, 2, 0)
+		}
+		json.NewEncoder(w).Encode(sub)
+	})
+	mux.HandleFunc(fmt.Sprintf("GET /%s/checkouts", APIVersion), mock.HandleGetCheckout)
+	mux.HandleFunc(fmt.Sprintf("POST /%s/licenses/validate", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		var req LicenseValidateRequest
+		a.NoError(json.NewDecoder(r.Body).Decode(&req))
+		a.Equal(LicenseValidateRequest{Key: "ABC-123", InstanceID: "ins_1"}, req)
+		mock.HandlePostValidateLicense(w, r)
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+
+	overview, err := c.Customers.OverviewWithOptions(context.Background(), CustomerRequestQuery{Email: "user@example.com"}, &CustomerOverviewOptions{
+		CheckoutIDs: []string{"ch_1"},
+		Licenses:    []LicenseValidateRequest{{Key: "ABC-123", InstanceID: "ins_1"}},
+	})
+	a.NoError(err)
+
+	a.Equal("cus_abc123", overview.Customer.ID)
+	a.Len(overview.Transactions, 4)
+	a.Len(overview.Subscriptions, 2)
+	a.True(overview.CheckoutsResolved)
+	a.Len(overview.Checkouts, 1)
+	a.True(overview.LicensesResolved)
+	a.Len(overview.Licenses, 1)
+	a.Equal(map[string]int{"USD": 3500, "EUR": 900}, overview.LifetimeValue)
+	a.Equal("sub_2", overview.CurrentPlan.ID)
+	a.NotEmpty(overview.BillingPortalURL)
+}
+
+func TestCustomers_OverviewPartialFailure(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET /%s/customers", APIVersion), mock.HandleGetCustomer)
+	mux.HandleFunc(fmt.Sprintf("POST /%s/customers/billing", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc(fmt.Sprintf("GET /%s/transactions/search", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(TransactionList{})
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+
+	overview, err := c.Customers.Overview(context.Background(), CustomerRequestQuery{ID: "cus_abc123"})
+	a.ErrorContains(err, "billing portal")
+	a.NotNil(overview)
+	a.Equal("cus_abc123", overview.Customer.ID)
+	// Checkouts and licenses cannot be looked up by customer.
+	a.False(overview.CheckoutsResolved)
+	a.Nil(overview.Checkouts)
+	a.False(overview.LicensesResolved)
+	a.Nil(overview.Licenses)
+	a.Nil(overview.CurrentPlan)
+	a.Empty(overview.BillingPortalURL)
+
+	// Validating a license needs an instance, no request is sent without.
+	overview, err = c.Customers.OverviewWithOptions(context.Background(), CustomerRequestQuery{ID: "cus_abc123"}, &CustomerOverviewOptions{
+		Licenses: []LicenseValidateRequest{{Key: "ABC-123"}},
+	})
+	a.ErrorIs(err, errRequiredFieldInstanceID)
+	a.True(overview.LicensesResolved)
+	a.Nil(overview.Licenses)
+
+	_, err = c.Customers.Overview(context.Background(), CustomerRequestQuery{})
+	a.ErrorIs(err, errCustomerNoQuery)
+}
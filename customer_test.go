@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/evolvedevlab/creemio-go/mock"
 	"github.com/stretchr/testify/assert"
@@ -206,3 +207,140 @@ func TestCustomers_GetBillingPortalURL_WithError(t *testing.T) {
 	a.NotNil(res)
 	a.Equal(http.StatusInternalServerError, res.Status)
 }
+
+func TestCustomers_ListFiltered(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	pages := map[string]CustomerList{
+		"1": {
+			Items: []Customer{
+				{ID: "cus_1", Email: "ann@example.com", Country: "US", CreatedAt: day(1)},
+				{ID: "cus_2", Email: "bob@Example.com", Country: "DE", CreatedAt: day(5)},
+			},
+			Pagination: Pagination{CurrentPage: 1, NextPage: 2},
+		},
+		"2": {
+			Items: []Customer{
+				{ID: "cus_3", Email: "carl@example.com", Country: "DE", CreatedAt: day(10)},
+				{ID: "cus_4", Email: "dan@other.org", Country: "DE", CreatedAt: day(6)},
+			},
+			Pagination: Pagination{CurrentPage: 2},
+		},
+	}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Empty(r.URL.Query().Get("email"))
+		json.NewEncoder(w).Encode(pages[r.URL.Query().Get("page_number")])
+	}))
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+
+	query := &CustomerListQuery{
+		PageNumber:    1,
+		Email:         "EXAMPLE.com",
+		Country:       "de",
+		CreatedAfter:  day(5),
+		CreatedBefore: day(10),
+	}
+	list, _, err := c.Customers.List(context.Background(), query)
+	a.NoError(err)
+	a.Len(list.Items, 1)
+	a.Equal("cus_2", list.Items[0].ID)
+
+	var ids []string
+	for customer, err := range c.Customers.All(context.Background(), &CustomerListQuery{Country: "DE"}) {
+		a.NoError(err)
+		ids = append(ids, customer.ID)
+	}
+	a.Equal([]string{"cus_2", "cus_3", "cus_4"}, ids)
+}
+
+func TestCustomers_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal(http.MethodPost, r.Method)
+		a.Equal(fmt.Sprintf("/%s/customers", APIVersion), r.URL.Path)
+
+		var body map[string]any
+		a.NoError(json.NewDecoder(r.Body).Decode(&body))
+		a.Equal(map[string]any{"email": "user@example.com", "name": "John Doe", "country": "US"}, body)
+		mock.HandlePostCreateCustomer(w, r)
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	resp, res, err := c.Customers.Create(context.Background(), &CreateCustomerRequest{
+		Email:   "user@example.com",
+		Name:    "John Doe",
+		Country: "US",
+	})
+
+	a.NoError(err)
+	a.Equal(http.StatusOK, res.Status)
+	a.Equal("cus_abc123", resp.ID)
+}
+
+func TestCustomers_CreateValidation(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	c := New(WithBaseURL("http://127.0.0.1:0"))
+
+	_, _, err := c.Customers.Create(context.Background(), nil)
+	a.ErrorIs(err, errRequiredMissingField)
+
+	resp, res, err := c.Customers.Create(context.Background(), &CreateCustomerRequest{Country: "us"})
+	a.Nil(resp)
+	a.Nil(res)
+
+	var verr *ValidationError
+	a.ErrorAs(err, &verr)
+	a.Equal([]string{"email", "country"}, []string{verr.Errors[0].Field, verr.Errors[1].Field})
+
+	a.NoError((&CreateCustomerRequest{Email: "user@example.com", Country: "GB"}).Validate())
+	a.Error((&CreateCustomerRequest{Email: "user@example.com", Country: "UK"}).Validate())
+}
+
+func TestCustomers_Update(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal(http.MethodPost, r.Method)
+		a.Equal(fmt.Sprintf("/%s/customers/cus_abc123", APIVersion), r.URL.Path)
+
+		var body map[string]any
+		a.NoError(json.NewDecoder(r.Body).Decode(&body))
+		a.Equal(map[string]any{"country": "US"}, body)
+		mock.HandlePostUpdateCustomer(w, r)
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	resp, res, err := c.Customers.Update(context.Background(), &UpdateCustomerRequest{
+		CustomerID: "cus_abc123",
+		Country:    "US",
+	})
+
+	a.NoError(err)
+	a.Equal(http.StatusOK, res.Status)
+	a.Equal("US", resp.Country)
+
+	_, _, err = c.Customers.Update(context.Background(), &UpdateCustomerRequest{CustomerID: "cus_abc123"})
+	a.ErrorContains(err, "either name or country must be present")
+
+	_, _, err = c.Customers.Update(context.Background(), &UpdateCustomerRequest{Name: "Jane"})
+	a.ErrorContains(err, "customer_id: is required")
+}
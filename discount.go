@@ -7,6 +7,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -68,6 +69,27 @@ type CreateDiscountRequest struct {
 	DurationInMonths  int              `json:"duration_in_months,omitempty"`
 }
 
+type UpdateDiscountRequest struct {
+	DiscountID        string     `json:"-"`
+	Name              string     `json:"name,omitempty"`
+	ExpiryDate        *time.Time `json:"expiry_date,omitempty"`
+	MaxRedemptions    int        `json:"max_redemptions,omitempty"`
+	AppliesToProducts []string   `json:"applies_to_products,omitempty"`
+}
+
+type DiscountList struct {
+	Items      []Discount `json:"items"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type DiscountListQuery struct {
+	PageNumber int
+	PageSize   int
+	Status     DiscountStatus
+	Type       DiscountType
+	ProductID  string
+}
+
 type DiscountRequestQuery struct {
 	DiscountID   string
 	DiscountCode string
@@ -100,7 +122,7 @@ func (s *DiscountService) Get(ctx context.Context, query *DiscountRequestQuery)
 	}
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -141,7 +163,13 @@ func (s *DiscountService) Create(ctx context.Context, data *CreateDiscountReques
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-api-key", s.client.apiKey)
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service: "discounts",
+		action:  "create",
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateCreate(data), nil
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -173,7 +201,119 @@ func (s *DiscountService) Delete(ctx context.Context, id string) (*Discount, *Re
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-api-key", s.client.apiKey)
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service:    "discounts",
+		action:     "delete",
+		resourceID: id,
+		guarded:    true,
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateDelete(ctx, id)
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, newResponse(res, body), err
+	}
+	if res.StatusCode >= 400 {
+		return nil, newResponse(res, body), newAPIError(body)
+	}
+
+	var discount Discount
+	if err := json.Unmarshal(body, &discount); err != nil {
+		return nil, newResponse(res, body), err
+	}
+
+	return &discount, newResponse(res, body), nil
+}
+
+func (s *DiscountService) List(ctx context.Context, query *DiscountListQuery) (*DiscountList, *Response, error) {
+	targetUrl := makeUrl(s.client.baseURL, "/discounts", "search")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetUrl, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("x-api-key", s.client.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	if query != nil {
+		q := req.URL.Query()
+		if query.PageNumber > 0 {
+			q.Set("page_number", strconv.Itoa(query.PageNumber))
+		}
+		if query.PageSize > 0 {
+			q.Set("page_size", strconv.Itoa(query.PageSize))
+		}
+		if len(query.Status) > 0 {
+			q.Set("status", string(query.Status))
+		}
+		if len(query.Type) > 0 {
+			q.Set("type", string(query.Type))
+		}
+		if len(query.ProductID) > 0 {
+			q.Set("product_id", query.ProductID)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	res, err := s.client.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, newResponse(res, body), err
+	}
+	if res.StatusCode >= 400 {
+		return nil, newResponse(res, body), newAPIError(body)
+	}
+
+	var result DiscountList
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, newResponse(res, body), err
+	}
+
+	return &result, newResponse(res, body), nil
+}
+
+func (s *DiscountService) Update(ctx context.Context, data *UpdateDiscountRequest) (*Discount, *Response, error) {
+	if data == nil {
+		return nil, nil, errRequiredMissingField
+	}
+	if len(data.DiscountID) == 0 {
+		return nil, nil, errRequiredFieldDiscountID
+	}
+
+	targetUrl := makeUrl(s.client.baseURL, "/discounts", data.DiscountID)
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetUrl, bytes.NewReader(payload))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", s.client.apiKey)
+
+	res, err := s.client.doMutation(req, mutation{
+		service:    "discounts",
+		action:     "update",
+		resourceID: data.DiscountID,
+		guarded:    true,
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateUpdate(ctx, data)
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,298 @@
+package creemio
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// DefaultCodeAlphabet leaves out characters that are easily confused, such
+// as 0 and O or 1 and I.
+const DefaultCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// DefaultCodePattern is used when a campaign has no pattern.
+const DefaultCodePattern = "########"
+
+// CodePlaceholder is replaced with a random alphabet character in patterns.
+const CodePlaceholder = '#'
+
+// DefaultCampaignAttempts is the number of codes tried per discount before
+// giving up on collisions.
+const DefaultCampaignAttempts = 5
+
+var errCampaignKeyspace = errors.New("campaign: pattern does not allow enough unique codes")
+
+// DiscountCampaign describes a batch of discount codes sharing the same terms.
+type DiscountCampaign struct {
+	// Count is the number of codes to create.
+	Count int
+	// Pattern is the shape of each code, every CodePlaceholder is replaced
+	// with a random character, e.g. "SUMMER-####-####".
+	Pattern  string
+	Alphabet string
+	// Template holds the terms of every code. Code is ignored and a zero
+	// MaxRedemptions is treated as 1, so codes are single use by default.
+	Template CreateDiscountRequest
+	// Concurrency and Limiter control the create calls.
+	Concurrency int
+	Limiter     Limiter
+	// MaxAttempts bounds the retries of a code colliding with an existing one.
+	MaxAttempts int
+}
+
+// CampaignCode is the outcome of creating a single campaign code.
+type CampaignCode struct {
+	Code       string
+	DiscountID string
+	Err        error
+}
+
+func (c *DiscountCampaign) validate() error {
+	verr := &ValidationError{}
+	if c.Count <= 0 {
+		verr.add("count", "must be positive")
+	}
+	if len(c.Template.Name) == 0 {
+		verr.add("template.name", "is required")
+	}
+	alphabet := c.alphabet()
+	if len(alphabet) < 2 {
+		verr.add("alphabet", "needs at least two characters")
+	}
+	for _, r := range alphabet {
+		if r == CodePlaceholder || r > unicode.MaxASCII {
+			verr.add("alphabet", "must be ASCII and not contain the placeholder")
+			break
+		}
+	}
+	if err := verr.err(); err != nil {
+		return err
+	}
+
+	// Require the keyspace to be much larger than the campaign, otherwise
+	// collisions dominate.
+	n := strings.Count(c.pattern(), string(CodePlaceholder))
+	if float64(n)*math.Log2(float64(len(alphabet))) < math.Log2(float64(c.Count))+10 {
+		return errCampaignKeyspace
+	}
+	return nil
+}
+
+func (c *DiscountCampaign) pattern() string {
+	if len(c.Pattern) == 0 {
+		return DefaultCodePattern
+	}
+	return c.Pattern
+}
+
+func (c *DiscountCampaign) alphabet() string {
+	if len(c.Alphabet) == 0 {
+		return DefaultCodeAlphabet
+	}
+	return c.Alphabet
+}
+
+// GenerateCode fills every CodePlaceholder in pattern with a random
+// character from alphabet.
+func GenerateCode(pattern, alphabet string) (string, error) {
+	size := big.NewInt(int64(len(alphabet)))
+	var b strings.Builder
+	b.Grow(len(pattern))
+	for _, r := range pattern {
+		if r != CodePlaceholder {
+			b.WriteRune(r)
+			continue
+		}
+		i, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(alphabet[i.Int64()])
+	}
+	return b.String(), nil
+}
+
+// codeSet hands out codes unique within a campaign.
+type codeSet struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+func (s *codeSet) next(pattern, alphabet string) (string, error) {
+	for {
+		code, err := GenerateCode(pattern, alphabet)
+		if err != nil {
+			return "", err
+		}
+		s.mu.Lock()
+		_, dup := s.seen[code]
+		if !dup {
+			s.seen[code] = struct{}{}
+		}
+		s.mu.Unlock()
+		if !dup {
+			return code, nil
+		}
+	}
+}
+
+// isCodeCollision reports whether err means the code is already taken.
+func isCodeCollision(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Status == http.StatusConflict {
+		return true
+	}
+	msg := strings.ToLower(fmt.Sprint(apiErr.Err, " ", apiErr.Message))
+	return strings.Contains(msg, "already exists") || strings.Contains(msg, "already in use")
+}
+
+// CreateCampaign creates the codes of a campaign concurrently. Codes that
+// collide with existing ones are regenerated. The returned slice has one
+// entry per code, failed codes carry their error. The error is only set if
+// the campaign itself is invalid.
+func (s *DiscountService) CreateCampaign(ctx context.Context, campaign *DiscountCampaign) ([]CampaignCode, error) {
+	if campaign == nil {
+		return nil, errRequiredMissingField
+	}
+	if err := campaign.validate(); err != nil {
+		return nil, err
+	}
+
+	attempts := campaign.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultCampaignAttempts
+	}
+	pattern, alphabet := campaign.pattern(), campaign.alphabet()
+	codes := &codeSet{seen: make(map[string]struct{}, campaign.Count)}
+
+	slots := make([]string, campaign.Count)
+	results := getMany(ctx, slots, &BatchOptions{
+		Concurrency: campaign.Concurrency,
+		Limiter:     campaign.Limiter,
+	}, func(ctx context.Context, _ string) (*CampaignCode, *Response, error) {
+		var out CampaignCode
+		for range attempts {
+			code, err := codes.next(pattern, alphabet)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			data := campaign.Template
+			data.Code = code
+			if data.MaxRedemptions == 0 {
+				data.MaxRedemptions = 1
+			}
+
+			out = CampaignCode{Code: code}
+			discount, res, err := s.Create(ctx, &data)
+			if err == nil {
+				out.DiscountID = discount.ID
+				return &out, res, nil
+			}
+			out.Err = err
+			if !isCodeCollision(err) {
+				break
+			}
+		}
+		return &out, nil, nil
+	})
+
+	out := make([]CampaignCode, len(results))
+	for i, r := range results {
+		if r.Value != nil {
+			out[i] = *r.Value
+		} else {
+			out[i].Err = r.Err
+		}
+	}
+	return out, nil
+}
+
+// DeleteCampaign deletes every successfully created code of a campaign.
+// Results are in the order of codes, codes without a discount ID are
+// skipped.
+func (s *DiscountService) DeleteCampaign(ctx context.Context, codes []CampaignCode, opts *BatchOptions) []BatchResult[Discount] {
+	return getMany(ctx, campaignDiscountIDs(codes), opts, s.Delete)
+}
+
+// ExpireCampaign sets the expiry date of every successfully created code of
+// a campaign to at, the zero time means now. Results are in the order of
+// codes, codes without a discount ID are skipped.
+func (s *DiscountService) ExpireCampaign(ctx context.Context, codes []CampaignCode, at time.Time, opts *BatchOptions) []BatchResult[Discount] {
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+	return getMany(ctx, campaignDiscountIDs(codes), opts, func(ctx context.Context, id string) (*Discount, *Response, error) {
+		return s.Update(ctx, &UpdateDiscountRequest{DiscountID: id, ExpiryDate: &at})
+	})
+}
+
+func campaignDiscountIDs(codes []CampaignCode) []string {
+	ids := make([]string, 0, len(codes))
+	for _, c := range codes {
+		if len(c.DiscountID) > 0 {
+			ids = append(ids, c.DiscountID)
+		}
+	}
+	return ids
+}
+
+var campaignCSVHeader = []string{"code", "discount_id", "error"}
+
+// WriteCampaignCSV writes codes as CSV with a code, discount_id and error
+// column.
+func WriteCampaignCSV(w io.Writer, codes []CampaignCode) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(campaignCSVHeader); err != nil {
+		return err
+	}
+	for _, c := range codes {
+		var errMsg string
+		if c.Err != nil {
+			errMsg = c.Err.Error()
+		}
+		if err := cw.Write([]string{c.Code, c.DiscountID, errMsg}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCampaignCSV reads codes written by WriteCampaignCSV, e.g. to delete a
+// campaign later.
+func ReadCampaignCSV(r io.Reader) ([]CampaignCode, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(campaignCSVHeader)
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(campaignCSVHeader, ",") {
+		return nil, errors.New("campaign: unexpected CSV header")
+	}
+
+	codes := make([]CampaignCode, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		c := CampaignCode{Code: rec[0], DiscountID: rec[1]}
+		if len(rec[2]) > 0 {
+			c.Err = errors.New(rec[2])
+		}
+		codes = append(codes, c)
+	}
+	return codes, nil
+}
@@ -0,0 +1,144 @@
+package creemio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateCode(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	code, err := GenerateCode("SUMMER-####-####", DefaultCodeAlphabet)
+	a.NoError(err)
+	a.Regexp(regexp.MustCompile(`^SUMMER-[2-9A-HJ-NP-Z]{4}-[2-9A-HJ-NP-Z]{4}$`), code)
+}
+
+func TestDiscountCampaign(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var (
+		mu      sync.Mutex
+		created = map[string]CreateDiscountRequest{}
+		deleted []string
+		expired []string
+		// The first create collides with an existing code.
+		collided atomic.Bool
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("POST /%s/discounts", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		var data CreateDiscountRequest
+		json.NewDecoder(r.Body).Decode(&data)
+
+		if collided.CompareAndSwap(false, true) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"trace_id": "trace_1", "status": 409, "error": "Conflict"}`))
+			return
+		}
+
+		mu.Lock()
+		id := fmt.Sprintf("dis_%d", len(created))
+		created[data.Code] = data
+		mu.Unlock()
+
+		json.NewEncoder(w).Encode(Discount{ID: id, Code: data.Code, Name: data.Name})
+	})
+	mux.HandleFunc(fmt.Sprintf("POST /%s/discounts/{id}", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		var data UpdateDiscountRequest
+		json.NewDecoder(r.Body).Decode(&data)
+		mu.Lock()
+		expired = append(expired, r.PathValue("id"))
+		mu.Unlock()
+		json.NewEncoder(w).Encode(Discount{ID: r.PathValue("id"), ExpiryDate: data.ExpiryDate})
+	})
+	mux.HandleFunc(fmt.Sprintf("DELETE /%s/discounts/{id}/delete", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		deleted = append(deleted, r.PathValue("id"))
+		mu.Unlock()
+		json.NewEncoder(w).Encode(Discount{ID: r.PathValue("id")})
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	codes, err := c.Discounts.CreateCampaign(context.Background(), &DiscountCampaign{
+		Count:       25,
+		Pattern:     "SPRING-######",
+		Concurrency: 4,
+		Template: CreateDiscountRequest{
+			Name:              "Spring",
+			Type:              DiscountTypePercentage,
+			Percentage:        20,
+			Duration:          DiscountDurationOnce,
+			ExpiryDate:        &expiry,
+			AppliesToProducts: []string{"prod_1"},
+		},
+	})
+	a.NoError(err)
+	a.Len(codes, 25)
+	a.Len(created, 25)
+
+	for _, code := range codes {
+		a.NoError(code.Err)
+		a.NotEmpty(code.DiscountID)
+		data, ok := created[code.Code]
+		a.True(ok)
+		a.Equal(1, data.MaxRedemptions)
+		a.Equal([]string{"prod_1"}, data.AppliesToProducts)
+		a.True(expiry.Equal(*data.ExpiryDate))
+	}
+
+	var buf bytes.Buffer
+	a.NoError(WriteCampaignCSV(&buf, codes))
+	read, err := ReadCampaignCSV(&buf)
+	a.NoError(err)
+	a.Equal(codes, read)
+
+	results := c.Discounts.ExpireCampaign(context.Background(), read, expiry, nil)
+	a.Len(results, 25)
+	for _, r := range results {
+		a.NoError(r.Err)
+		a.True(expiry.Equal(*r.Value.ExpiryDate))
+	}
+	a.Len(expired, 25)
+
+	results = c.Discounts.DeleteCampaign(context.Background(), read, nil)
+	a.Len(results, 25)
+	for _, r := range results {
+		a.NoError(r.Err)
+	}
+	a.Len(deleted, 25)
+}
+
+func TestDiscountCampaign_Invalid(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	c := New(WithBaseURL("http://localhost"))
+
+	_, err := c.Discounts.CreateCampaign(context.Background(), &DiscountCampaign{Count: 10})
+	var verr *ValidationError
+	a.ErrorAs(err, &verr)
+
+	_, err = c.Discounts.CreateCampaign(context.Background(), &DiscountCampaign{
+		Count:    1000,
+		Pattern:  "CODE-##",
+		Template: CreateDiscountRequest{Name: "Tiny"},
+	})
+	a.ErrorIs(err, errCampaignKeyspace)
+}
@@ -0,0 +1,163 @@
+package creemio
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Reasons a discount does not apply, see PriceQuote.NotApplicable.
+var (
+	ErrDiscountNotActive        = errors.New("discount is not active")
+	ErrDiscountExpired          = errors.New("discount has expired")
+	ErrDiscountProductMismatch  = errors.New("discount does not apply to this product")
+	ErrDiscountCurrencyMismatch = errors.New("discount currency does not match the product currency")
+)
+
+var errUnknownBillingPeriod = errors.New("unknown billing period")
+
+// billingPeriodMonths maps billing periods to their length in months.
+var billingPeriodMonths = map[string]int{
+	"every-month":        1,
+	"every-three-months": 3,
+	"every-six-months":   6,
+	"every-year":         12,
+}
+
+// BillingPeriodMonths returns the length of a recurring billing period in
+// months, false for unknown periods.
+func BillingPeriodMonths(period string) (int, bool) {
+	months, ok := billingPeriodMonths[period]
+	return months, ok
+}
+
+// PeriodCharge is the amount charged for one billing period.
+type PeriodCharge struct {
+	// Period starts at 1 for the first charge.
+	Period   int `json:"period"`
+	Amount   int `json:"amount"`
+	Discount int `json:"discount"`
+}
+
+// PriceQuote is the price of a product with a discount applied, amounts are
+// in the smallest currency unit like Product.Price.
+type PriceQuote struct {
+	Currency    string         `json:"currency"`
+	Units       int            `json:"units"`
+	Subtotal    int            `json:"subtotal"`
+	FirstCharge int            `json:"first_charge"`
+	Schedule    []PeriodCharge `json:"schedule"`
+	// NotApplicable explains why the discount was not applied, nil if it was.
+	NotApplicable error `json:"-"`
+}
+
+// QuoteDiscount computes what a customer pays for units of product with
+// discount applied, following Creem's rules: percentage discounts are
+// rounded to the nearest unit, fixed discounts never go below zero, "once"
+// discounts only reduce the first charge and "repeating" ones the charges
+// within DurationInMonths. The schedule covers periods billing periods, or a
+// single charge for one-time products. at is the time of purchase, used to
+// check the expiry date, the zero time means now.
+//
+// A discount that does not apply is not an error, the quote is returned at
+// full price with NotApplicable set. A nil discount quotes the full price.
+func QuoteDiscount(discount *Discount, product *Product, units, periods int, at time.Time) (*PriceQuote, error) {
+	if product == nil {
+		return nil, errRequiredMissingField
+	}
+	if units < 1 {
+		return nil, fmt.Errorf("units must be at least 1, got %d", units)
+	}
+
+	periodMonths := 0
+	if product.BillingType == BillingTypeOneTime {
+		periods = 1
+	} else {
+		months, ok := billingPeriodMonths[product.BillingPeriod]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errUnknownBillingPeriod, product.BillingPeriod)
+		}
+		periodMonths = months
+		if periods < 1 {
+			periods = 1
+		}
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	subtotal := product.Price * units
+	quote := &PriceQuote{
+		Currency: product.Currency,
+		Units:    units,
+		Subtotal: subtotal,
+		Schedule: make([]PeriodCharge, periods),
+	}
+
+	var amount int
+	if discount != nil {
+		quote.NotApplicable = discountApplies(discount, product, at)
+		if quote.NotApplicable == nil {
+			amount = discountAmount(discount, subtotal)
+		}
+	}
+
+	for i := range quote.Schedule {
+		charge := PeriodCharge{Period: i + 1, Amount: subtotal}
+		if amount > 0 && discountCoversPeriod(discount, i, periodMonths) {
+			charge.Discount = amount
+			charge.Amount -= amount
+		}
+		quote.Schedule[i] = charge
+	}
+	quote.FirstCharge = quote.Schedule[0].Amount
+
+	return quote, nil
+}
+
+// discountApplies returns the reason discount cannot be used for product,
+// or nil.
+func discountApplies(discount *Discount, product *Product, at time.Time) error {
+	if discount.Status != DiscountStatusActive {
+		return fmt.Errorf("%w: status is %q", ErrDiscountNotActive, discount.Status)
+	}
+	if discount.ExpiryDate != nil && !at.Before(*discount.ExpiryDate) {
+		return fmt.Errorf("%w on %s", ErrDiscountExpired, discount.ExpiryDate.Format(time.DateOnly))
+	}
+	if len(discount.AppliesToProducts) > 0 && !slices.Contains(discount.AppliesToProducts, product.ID) {
+		return ErrDiscountProductMismatch
+	}
+	if discount.Type == DiscountTypeFixed && !strings.EqualFold(discount.Currency, product.Currency) {
+		return fmt.Errorf("%w: %s and %s", ErrDiscountCurrencyMismatch, discount.Currency, product.Currency)
+	}
+	return nil
+}
+
+// discountAmount is the amount taken off a charge of subtotal.
+func discountAmount(discount *Discount, subtotal int) int {
+	var amount int
+	switch discount.Type {
+	case DiscountTypePercentage:
+		amount = (subtotal*discount.Percentage + 50) / 100
+	case DiscountTypeFixed:
+		amount = discount.Amount
+	}
+	return max(0, min(amount, subtotal))
+}
+
+// discountCoversPeriod reports whether the discount applies to the billing
+// period with the zero based index i.
+func discountCoversPeriod(discount *Discount, i, periodMonths int) bool {
+	switch discount.Duration {
+	case DiscountDurationOnce:
+		return i == 0
+	case DiscountDurationRepeating:
+		// A period is discounted if it starts within the discounted months.
+		return i*periodMonths < discount.DurationInMonths
+	default:
+		return true
+	}
+}
@@ -0,0 +1,157 @@
+package creemio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteDiscount(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	monthly := &Product{
+		ID:            "prod_1",
+		Price:         1999,
+		Currency:      "USD",
+		BillingType:   BillingTypeRecurring,
+		BillingPeriod: "every-month",
+	}
+	quarterly := &Product{
+		ID:            "prod_2",
+		Price:         5000,
+		Currency:      "EUR",
+		BillingType:   BillingTypeRecurring,
+		BillingPeriod: "every-three-months",
+	}
+	oneTime := &Product{
+		ID:          "prod_3",
+		Price:       10000,
+		Currency:    "USD",
+		BillingType: BillingTypeOneTime,
+	}
+
+	tests := []struct {
+		name          string
+		discount      *Discount
+		product       *Product
+		units         int
+		periods       int
+		expected      []int
+		notApplicable error
+	}{
+		{
+			name:     "no discount",
+			product:  monthly,
+			units:    1,
+			periods:  2,
+			expected: []int{1999, 1999},
+		},
+		{
+			name:     "percentage once rounds to nearest",
+			discount: &Discount{Status: DiscountStatusActive, Type: DiscountTypePercentage, Percentage: 15, Duration: DiscountDurationOnce},
+			product:  monthly,
+			units:    1,
+			periods:  3,
+			expected: []int{1699, 1999, 1999},
+		},
+		{
+			name:     "percentage forever with units",
+			discount: &Discount{Status: DiscountStatusActive, Type: DiscountTypePercentage, Percentage: 50, Duration: DiscountDurationForever},
+			product:  monthly,
+			units:    3,
+			periods:  2,
+			expected: []int{2998, 2998},
+		},
+		{
+			name:     "repeating counts months not periods",
+			discount: &Discount{Status: DiscountStatusActive, Type: DiscountTypeFixed, Amount: 1000, Currency: "eur", Duration: DiscountDurationRepeating, DurationInMonths: 4},
+			product:  quarterly,
+			units:    1,
+			periods:  3,
+			expected: []int{4000, 4000, 5000},
+		},
+		{
+			name:     "fixed never below zero",
+			discount: &Discount{Status: DiscountStatusActive, Type: DiscountTypeFixed, Amount: 20000, Currency: "USD", Duration: DiscountDurationOnce},
+			product:  oneTime,
+			units:    1,
+			periods:  12,
+			expected: []int{0},
+		},
+		{
+			name:          "inactive",
+			discount:      &Discount{Status: DiscountStatusDraft, Type: DiscountTypePercentage, Percentage: 10},
+			product:       monthly,
+			units:         1,
+			periods:       1,
+			expected:      []int{1999},
+			notApplicable: ErrDiscountNotActive,
+		},
+		{
+			name:          "expired",
+			discount:      &Discount{Status: DiscountStatusActive, Type: DiscountTypePercentage, Percentage: 10, ExpiryDate: &past},
+			product:       monthly,
+			units:         1,
+			periods:       1,
+			expected:      []int{1999},
+			notApplicable: ErrDiscountExpired,
+		},
+		{
+			name:          "other product",
+			discount:      &Discount{Status: DiscountStatusActive, Type: DiscountTypePercentage, Percentage: 10, AppliesToProducts: []string{"prod_2"}},
+			product:       monthly,
+			units:         1,
+			periods:       1,
+			expected:      []int{1999},
+			notApplicable: ErrDiscountProductMismatch,
+		},
+		{
+			name:          "other currency",
+			discount:      &Discount{Status: DiscountStatusActive, Type: DiscountTypeFixed, Amount: 100, Currency: "EUR"},
+			product:       monthly,
+			units:         1,
+			periods:       1,
+			expected:      []int{1999},
+			notApplicable: ErrDiscountCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			quote, err := QuoteDiscount(tt.discount, tt.product, tt.units, tt.periods, now)
+			a.NoError(err)
+
+			var charges []int
+			for _, c := range quote.Schedule {
+				charges = append(charges, c.Amount)
+			}
+			a.Equal(tt.expected, charges)
+			a.Equal(tt.expected[0], quote.FirstCharge)
+			a.Equal(tt.product.Price*tt.units, quote.Subtotal)
+			if tt.notApplicable != nil {
+				a.ErrorIs(quote.NotApplicable, tt.notApplicable)
+			} else {
+				a.NoError(quote.NotApplicable)
+			}
+		})
+	}
+}
+
+func TestQuoteDiscount_InvalidInput(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	_, err := QuoteDiscount(nil, nil, 1, 1, time.Time{})
+	a.Error(err)
+
+	_, err = QuoteDiscount(nil, &Product{BillingPeriod: "every-month"}, 0, 1, time.Time{})
+	a.Error(err)
+
+	_, err = QuoteDiscount(nil, &Product{BillingType: BillingTypeRecurring, BillingPeriod: "every-week"}, 1, 1, time.Time{})
+	a.ErrorIs(err, errUnknownBillingPeriod)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/evolvedevlab/creemio-go/mock"
 	"github.com/stretchr/testify/assert"
@@ -239,3 +240,161 @@ func TestDiscounts_GetWithError(t *testing.T) {
 	a.NotNil(res)
 	a.Equal(http.StatusInternalServerError, res.Status)
 }
+
+func TestDiscounts_List(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandleGetDiscountList))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	query := &DiscountListQuery{
+		PageNumber: 2,
+		PageSize:   10,
+		Status:     DiscountStatusActive,
+		Type:       DiscountTypeFixed,
+		ProductID:  "prod_10rxEcEUn5fYkSBpXWhdjR",
+	}
+
+	// For comparing the url request url with search params
+	url, err := url.Parse(fmt.Sprintf("/%s/discounts/search", APIVersion))
+	if err != nil {
+		panic(err)
+	}
+	q := url.Query()
+	q.Set("page_number", "2")
+	q.Set("page_size", "10")
+	q.Set("status", "active")
+	q.Set("type", "fixed")
+	q.Set("product_id", query.ProductID)
+	url.RawQuery = q.Encode()
+
+	resp, res, err := c.Discounts.List(context.Background(), query)
+
+	a.NoError(err)
+	a.Equal(url.RequestURI(), res.RequestURL.RequestURI())
+	a.Equal(http.StatusOK, res.Status)
+	a.NotNil(resp)
+
+	var expected DiscountList
+	err = json.Unmarshal(mock.GetDiscountListResponse(), &expected)
+
+	a.NoError(err)
+	a.Equal(expected, *resp)
+	a.Len(resp.Items, 2)
+}
+
+func TestDiscounts_ListWithError(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	resp, res, err := c.Discounts.List(context.Background(), nil)
+
+	a.Error(err)
+	a.Nil(resp)
+	a.NotNil(res)
+	a.Equal(http.StatusInternalServerError, res.Status)
+}
+
+func TestDiscounts_Update(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var payload map[string]any
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+		mock.HandlePostUpdateDiscount(w, r)
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	discountID := "1"
+	// For comparing the url request url with search params
+	url, err := url.Parse(fmt.Sprintf("/%s/discounts/%s", APIVersion, discountID))
+	if err != nil {
+		panic(err)
+	}
+
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	resp, res, err := c.Discounts.Update(context.Background(), &UpdateDiscountRequest{
+		DiscountID:     discountID,
+		ExpiryDate:     &expiry,
+		MaxRedemptions: 50,
+	})
+
+	a.NoError(err)
+	a.Equal(url.RequestURI(), res.RequestURL.RequestURI())
+	a.Equal(http.StatusOK, res.Status)
+	a.NotNil(resp)
+	a.Equal(map[string]any{"expiry_date": "2030-01-01T00:00:00Z", "max_redemptions": float64(50)}, payload)
+
+	var expected Discount
+	err = json.Unmarshal(mock.GetDiscountResponse(), &expected)
+
+	a.NoError(err)
+	a.Equal(expected, *resp)
+}
+
+func TestDiscounts_UpdateWithMissingRequiredField(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandlePostUpdateDiscount))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	resp, res, err := c.Discounts.Update(context.Background(), &UpdateDiscountRequest{Name: "KING50"})
+
+	a.Error(err)
+	a.Nil(resp)
+	a.Nil(res)
+	a.EqualError(err, errRequiredFieldDiscountID.Error())
+}
+
+func TestDiscounts_UpdateWithError(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	resp, res, err := c.Discounts.Update(context.Background(), &UpdateDiscountRequest{
+		DiscountID: "1",
+		Name:       "KING50",
+	})
+
+	a.Error(err)
+	a.Nil(resp)
+	a.NotNil(res)
+	a.Equal(http.StatusInternalServerError, res.Status)
+}
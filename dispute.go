@@ -0,0 +1,451 @@
+package creemio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownDispute   = errors.New("unknown dispute")
+	errNotDisputeEvent  = errors.New("webhook is not a dispute event")
+	errInvalidDisputeID = errors.New("dispute ID is not a valid file name")
+)
+
+type DisputeState string
+
+const (
+	// DisputeStateOpen is set when a dispute is received.
+	DisputeStateOpen DisputeState = "open"
+	DisputeStateWon  DisputeState = "won"
+	DisputeStateLost DisputeState = "lost"
+)
+
+// DisputeAction is run once for every new dispute. Failed actions are retried
+// when the same dispute is handled again.
+type DisputeAction struct {
+	Name string
+	Run  func(ctx context.Context, dispute *Dispute) error
+}
+
+// DisputeActionResult is the outcome of the last run of an action.
+type DisputeActionResult struct {
+	Name  string    `json:"name"`
+	RanAt time.Time `json:"ran_at"`
+	Error string    `json:"error,omitempty"`
+}
+
+// DisputeRecord is the local state of a dispute.
+type DisputeRecord struct {
+	Dispute    Dispute               `json:"dispute"`
+	State      DisputeState          `json:"state"`
+	ReceivedAt time.Time             `json:"received_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+	Actions    []DisputeActionResult `json:"actions"`
+}
+
+// Failed reports whether any action failed on its last run.
+func (r *DisputeRecord) Failed() bool {
+	return slices.ContainsFunc(r.Actions, func(a DisputeActionResult) bool {
+		return len(a.Error) > 0
+	})
+}
+
+func (r *DisputeRecord) succeeded(action string) bool {
+	return slices.ContainsFunc(r.Actions, func(a DisputeActionResult) bool {
+		return a.Name == action && len(a.Error) == 0
+	})
+}
+
+func (r *DisputeRecord) setResult(result DisputeActionResult) {
+	for i, a := range r.Actions {
+		if a.Name == result.Name {
+			r.Actions[i] = result
+			return
+		}
+	}
+	r.Actions = append(r.Actions, result)
+}
+
+// DisputeStore keeps dispute records. Get returns ErrUnknownDispute for
+// disputes it has not seen.
+type DisputeStore interface {
+	Get(ctx context.Context, id string) (*DisputeRecord, error)
+	Save(ctx context.Context, record *DisputeRecord) error
+	List(ctx context.Context) ([]*DisputeRecord, error)
+}
+
+// MemoryDisputeStore keeps dispute records in memory.
+type MemoryDisputeStore struct {
+	mu      sync.Mutex
+	records map[string]*DisputeRecord
+}
+
+func NewMemoryDisputeStore() *MemoryDisputeStore {
+	return &MemoryDisputeStore{records: make(map[string]*DisputeRecord)}
+}
+
+func (s *MemoryDisputeStore) Get(ctx context.Context, id string) (*DisputeRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		return nil, ErrUnknownDispute
+	}
+	return cloneDisputeRecord(record), nil
+}
+
+func (s *MemoryDisputeStore) Save(ctx context.Context, record *DisputeRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[record.Dispute.ID] = cloneDisputeRecord(record)
+	return nil
+}
+
+func (s *MemoryDisputeStore) List(ctx context.Context) ([]*DisputeRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := make([]*DisputeRecord, 0, len(s.records))
+	for _, r := range s.records {
+		records = append(records, cloneDisputeRecord(r))
+	}
+	slices.SortFunc(records, func(a, b *DisputeRecord) int {
+		return a.ReceivedAt.Compare(b.ReceivedAt)
+	})
+	return records, nil
+}
+
+func cloneDisputeRecord(r *DisputeRecord) *DisputeRecord {
+	c := *r
+	c.Actions = slices.Clone(r.Actions)
+	return &c
+}
+
+// FileDisputeStore keeps dispute records in memory and writes all of them to
+// a JSON file on every change.
+type FileDisputeStore struct {
+	path string
+
+	mu    sync.Mutex
+	store *MemoryDisputeStore
+}
+
+// NewFileDisputeStore loads the records at path, a missing file starts an
+// empty store.
+func NewFileDisputeStore(path string) (*FileDisputeStore, error) {
+	s := &FileDisputeStore{path: path, store: NewMemoryDisputeStore()}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*DisputeRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("dispute store %s: %w", path, err)
+	}
+	for _, r := range records {
+		s.store.records[r.Dispute.ID] = r
+	}
+	return s, nil
+}
+
+func (s *FileDisputeStore) Get(ctx context.Context, id string) (*DisputeRecord, error) {
+	return s.store.Get(ctx, id)
+}
+
+func (s *FileDisputeStore) List(ctx context.Context) ([]*DisputeRecord, error) {
+	return s.store.List(ctx)
+}
+
+func (s *FileDisputeStore) Save(ctx context.Context, record *DisputeRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.Save(ctx, record); err != nil {
+		return err
+	}
+	records, err := s.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, data)
+}
+
+// writeFileAtomic replaces path with data, readers see either the old or
+// the new content.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// DisputeWorkflow runs actions on dispute webhooks and tracks the state of
+// every dispute in a DisputeStore.
+type DisputeWorkflow struct {
+	store   DisputeStore
+	actions []DisputeAction
+	now     func() time.Time
+
+	// mu serializes handling, so a redelivered webhook does not run actions
+	// twice.
+	mu sync.Mutex
+}
+
+// NewDisputeWorkflow runs actions in order for every new dispute. A nil store
+// keeps records in memory.
+func NewDisputeWorkflow(store DisputeStore, actions ...DisputeAction) *DisputeWorkflow {
+	if store == nil {
+		store = NewMemoryDisputeStore()
+	}
+	return &DisputeWorkflow{
+		store:   store,
+		actions: actions,
+		now:     time.Now,
+	}
+}
+
+// HandleWebHook handles the body of a dispute.created webhook. Verify the
+// signature with VerifyWebHookSignature first.
+func (w *DisputeWorkflow) HandleWebHook(ctx context.Context, body []byte) (*DisputeRecord, error) {
+	var event WebHookDisputeRequest
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	if event.EventType != WebHookEventDisputeCreated {
+		return nil, fmt.Errorf("%w: %s", errNotDisputeEvent, event.EventType)
+	}
+	return w.Handle(ctx, &event.DisputeObject)
+}
+
+// Handle records the dispute and runs every action that has not succeeded
+// for it yet. Action failures are recorded rather than returned, see
+// DisputeRecord.Failed. The error is only set if the store fails.
+func (w *DisputeWorkflow) Handle(ctx context.Context, dispute *Dispute) (*DisputeRecord, error) {
+	if dispute == nil || len(dispute.ID) == 0 {
+		return nil, errRequiredMissingField
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	record, err := w.store.Get(ctx, dispute.ID)
+	if errors.Is(err, ErrUnknownDispute) {
+		now := w.now().UTC()
+		record = &DisputeRecord{
+			Dispute:    *dispute,
+			State:      DisputeStateOpen,
+			ReceivedAt: now,
+			UpdatedAt:  now,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, action := range w.actions {
+		if record.succeeded(action.Name) {
+			continue
+		}
+		result := DisputeActionResult{Name: action.Name, RanAt: w.now().UTC()}
+		if err := action.Run(ctx, dispute); err != nil {
+			result.Error = err.Error()
+		}
+		record.setResult(result)
+		record.UpdatedAt = result.RanAt
+	}
+
+	if err := w.store.Save(ctx, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Resolve sets the final state of a dispute, e.g. once the outcome is known
+// from the dashboard.
+func (w *DisputeWorkflow) Resolve(ctx context.Context, id string, state DisputeState) (*DisputeRecord, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	record, err := w.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	record.State = state
+	record.UpdatedAt = w.now().UTC()
+	if err := w.store.Save(ctx, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// DisputeReport summarizes the tracked disputes.
+type DisputeReport struct {
+	Total   int                  `json:"total"`
+	ByState map[DisputeState]int `json:"by_state"`
+	// Amounts sums disputed amounts per currency.
+	Amounts map[string]int `json:"amounts"`
+	// Failed lists the IDs of disputes with failed actions.
+	Failed []string `json:"failed,omitempty"`
+}
+
+func (w *DisputeWorkflow) Report(ctx context.Context) (*DisputeReport, error) {
+	records, err := w.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DisputeReport{
+		Total:   len(records),
+		ByState: make(map[DisputeState]int),
+		Amounts: make(map[string]int),
+	}
+	for _, r := range records {
+		report.ByState[r.State]++
+		report.Amounts[r.Dispute.Currency] += r.Dispute.Amount
+		if r.Failed() {
+			report.Failed = append(report.Failed, r.Dispute.ID)
+		}
+	}
+	return report, nil
+}
+
+// SuspendEntitlementsAction calls suspend with the ID of the disputing
+// customer, e.g. to revoke access in the application.
+func SuspendEntitlementsAction(suspend func(ctx context.Context, customerID string) error) DisputeAction {
+	return DisputeAction{
+		Name: "suspend_entitlements",
+		Run: func(ctx context.Context, dispute *Dispute) error {
+			if dispute.Customer == nil || len(dispute.Customer.ID) == 0 {
+				return errors.New("dispute has no customer")
+			}
+			return suspend(ctx, dispute.Customer.ID)
+		},
+	}
+}
+
+// PauseSubscriptionAction pauses the subscription linked to the dispute.
+// Disputes without a subscription are skipped.
+func PauseSubscriptionAction(subscriptions *SubscriptionService) DisputeAction {
+	return DisputeAction{
+		Name: "pause_subscription",
+		Run: func(ctx context.Context, dispute *Dispute) error {
+			if dispute.Subscription == nil || len(dispute.Subscription.ID) == 0 {
+				return nil
+			}
+			_, _, err := subscriptions.Pause(ctx, dispute.Subscription.ID)
+			return err
+		},
+	}
+}
+
+// DisputeEvidence is the customer history collected for a dispute.
+type DisputeEvidence struct {
+	CollectedAt  time.Time     `json:"collected_at"`
+	Dispute      Dispute       `json:"dispute"`
+	Customer     *Customer     `json:"customer,omitempty"`
+	Subscription *Subscription `json:"subscription,omitempty"`
+	Checkout     *Checkout     `json:"checkout,omitempty"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+// EvidencePath is the file EvidenceBundleAction writes the evidence of a
+// dispute to. The dispute ID comes from the webhook payload, IDs that are
+// not a plain file name within dir are rejected.
+func EvidencePath(dir, disputeID string) (string, error) {
+	if !filepath.IsLocal(disputeID) || strings.ContainsAny(disputeID, `/\`) {
+		return "", fmt.Errorf("%w: %q", errInvalidDisputeID, disputeID)
+	}
+	return filepath.Join(dir, disputeID+".json"), nil
+}
+
+// EvidenceBundleAction snapshots the customer, the linked subscription and
+// checkout and all transactions of the customer into a JSON file in dir,
+// see EvidencePath.
+func EvidenceBundleAction(client *Client, dir string) DisputeAction {
+	return DisputeAction{
+		Name: "evidence_bundle",
+		Run: func(ctx context.Context, dispute *Dispute) error {
+			path, err := EvidencePath(dir, dispute.ID)
+			if err != nil {
+				return err
+			}
+			evidence, err := collectDisputeEvidence(ctx, client, dispute)
+			if err != nil {
+				return err
+			}
+			data, err := json.MarshalIndent(evidence, "", "  ")
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(dir, 0o700); err != nil {
+				return err
+			}
+			return writeFileAtomic(path, data)
+		},
+	}
+}
+
+func collectDisputeEvidence(ctx context.Context, client *Client, dispute *Dispute) (*DisputeEvidence, error) {
+	evidence := &DisputeEvidence{
+		CollectedAt:  time.Now().UTC(),
+		Dispute:      *dispute,
+		Transactions: []Transaction{},
+	}
+
+	if dispute.Customer != nil && len(dispute.Customer.ID) > 0 {
+		customer, _, err := client.Customers.Get(ctx, &CustomerRequestQuery{ID: dispute.Customer.ID})
+		if err != nil {
+			return nil, fmt.Errorf("customer: %w", err)
+		}
+		evidence.Customer = customer
+
+		transactions, err := client.Transactions.listAll(ctx, &TransactionListQuery{CustomerID: dispute.Customer.ID})
+		if err != nil {
+			return nil, fmt.Errorf("transactions: %w", err)
+		}
+		evidence.Transactions = transactions
+	}
+	if dispute.Subscription != nil && len(dispute.Subscription.ID) > 0 {
+		subscription, _, err := client.Subscriptions.Get(ctx, dispute.Subscription.ID)
+		if err != nil {
+			return nil, fmt.Errorf("subscription: %w", err)
+		}
+		evidence.Subscription = subscription
+	}
+	if dispute.Checkout != nil && len(dispute.Checkout.ID) > 0 {
+		checkout, _, err := client.Checkouts.Get(ctx, dispute.Checkout.ID)
+		if err != nil {
+			return nil, fmt.Errorf("checkout: %w", err)
+		}
+		evidence.Checkout = checkout
+	}
+	return evidence, nil
+}
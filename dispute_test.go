@@ -0,0 +1,193 @@
+package creemio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDisputeWebHook(id string) []byte {
+	body, _ := json.Marshal(map[string]any{
+		"id":        "evt_" + id,
+		"eventType": WebHookEventDisputeCreated,
+		"object": map[string]any{
+			"id":           id,
+			"object":       "dispute",
+			"amount":       2000,
+			"currency":     "USD",
+			"customer":     map[string]any{"id": "cust_1"},
+			"subscription": map[string]any{"id": "sub_1"},
+			"checkout":     map[string]any{"id": "ch_1"},
+		},
+	})
+	return body
+}
+
+func TestDisputeWorkflow(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var paused atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("POST /%s/subscriptions/{id}/pause", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		paused.Add(1)
+		mock.HandlePostPauseSubscription(w, r)
+	})
+	mux.HandleFunc(fmt.Sprintf("GET /%s/customers", APIVersion), mock.HandleGetCustomer)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/subscriptions", APIVersion), mock.HandleGetSubscription)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/checkouts", APIVersion), mock.HandleGetCheckout)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/transactions/search", APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("cust_1", r.URL.Query().Get("customer_id"))
+		page := r.URL.Query().Get("page_number")
+		next := 2
+		if page == "2" {
+			next = 0
+		}
+		json.NewEncoder(w).Encode(TransactionList{
+			Items:      []Transaction{{ID: "txn_" + page}},
+			Pagination: Pagination{CurrentPage: 1, NextPage: next},
+		})
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+	dir := t.TempDir()
+
+	// Suspending fails on the first delivery.
+	var suspended []string
+	failSuspend := true
+	suspend := SuspendEntitlementsAction(func(ctx context.Context, customerID string) error {
+		if failSuspend {
+			return errors.New("entitlement service unavailable")
+		}
+		suspended = append(suspended, customerID)
+		return nil
+	})
+
+	store, err := NewFileDisputeStore(filepath.Join(dir, "disputes.json"))
+	a.NoError(err)
+	w := NewDisputeWorkflow(store,
+		suspend,
+		PauseSubscriptionAction(c.Subscriptions),
+		EvidenceBundleAction(c, dir),
+	)
+
+	record, err := w.HandleWebHook(context.Background(), newDisputeWebHook("dis_1"))
+	a.NoError(err)
+	a.Equal(DisputeStateOpen, record.State)
+	a.True(record.Failed())
+	a.Len(record.Actions, 3)
+	a.EqualValues(1, paused.Load())
+
+	// Redelivery only retries the failed action.
+	failSuspend = false
+	record, err = w.HandleWebHook(context.Background(), newDisputeWebHook("dis_1"))
+	a.NoError(err)
+	a.False(record.Failed())
+	a.Equal([]string{"cust_1"}, suspended)
+	a.EqualValues(1, paused.Load())
+
+	path, err := EvidencePath(dir, "dis_1")
+	a.NoError(err)
+	data, err := os.ReadFile(path)
+	a.NoError(err)
+	var evidence DisputeEvidence
+	a.NoError(json.Unmarshal(data, &evidence))
+	a.Equal("dis_1", evidence.Dispute.ID)
+	a.NotNil(evidence.Customer)
+	a.NotNil(evidence.Subscription)
+	a.NotNil(evidence.Checkout)
+	a.Len(evidence.Transactions, 2)
+
+	_, err = w.Resolve(context.Background(), "dis_1", DisputeStateWon)
+	a.NoError(err)
+
+	// State survives a restart.
+	store, err = NewFileDisputeStore(filepath.Join(dir, "disputes.json"))
+	a.NoError(err)
+	report, err := NewDisputeWorkflow(store).Report(context.Background())
+	a.NoError(err)
+	a.Equal(1, report.Total)
+	a.Equal(1, report.ByState[DisputeStateWon])
+	a.Equal(2000, report.Amounts["USD"])
+	a.Empty(report.Failed)
+}
+
+func TestDisputeWorkflow_Report(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	w := NewDisputeWorkflow(nil, DisputeAction{
+		Name: "notify",
+		Run: func(ctx context.Context, dispute *Dispute) error {
+			if dispute.ID == "dis_2" {
+				return errors.New("failed")
+			}
+			return nil
+		},
+	})
+
+	for _, d := range []Dispute{
+		{ID: "dis_1", Amount: 1000, Currency: "USD"},
+		{ID: "dis_2", Amount: 500, Currency: "EUR"},
+		{ID: "dis_3", Amount: 300, Currency: "USD"},
+	} {
+		_, err := w.Handle(context.Background(), &d)
+		a.NoError(err)
+	}
+	_, err := w.Resolve(context.Background(), "dis_3", DisputeStateLost)
+	a.NoError(err)
+
+	_, err = w.Resolve(context.Background(), "dis_4", DisputeStateLost)
+	a.ErrorIs(err, ErrUnknownDispute)
+
+	report, err := w.Report(context.Background())
+	a.NoError(err)
+	a.Equal(3, report.Total)
+	a.Equal(map[DisputeState]int{DisputeStateOpen: 2, DisputeStateLost: 1}, report.ByState)
+	a.Equal(map[string]int{"USD": 1300, "EUR": 500}, report.Amounts)
+	a.Equal([]string{"dis_2"}, report.Failed)
+}
+
+func TestDisputeWorkflow_HandleWebHookOtherEvent(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	w := NewDisputeWorkflow(nil)
+	_, err := w.HandleWebHook(context.Background(), []byte(`{"id": "evt_1", "eventType": "refund.created", "object": {}}`))
+	a.ErrorIs(err, errNotDisputeEvent)
+}
+
+func TestEvidencePath(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	path, err := EvidencePath("/var/evidence", "dis_1")
+	a.NoError(err)
+	a.Equal(filepath.Join("/var/evidence", "dis_1.json"), path)
+
+	for _, id := range []string{"", "..", "../../etc/cron.d/x", "a/b", `a\b`, "/etc/passwd"} {
+		_, err := EvidencePath("/var/evidence", id)
+		a.ErrorIs(err, errInvalidDisputeID, id)
+	}
+
+	// The action fails before collecting or writing anything.
+	dir := t.TempDir()
+	action := EvidenceBundleAction(New(WithBaseURL("http://127.0.0.1:0")), filepath.Join(dir, "evidence"))
+	err = action.Run(context.Background(), &Dispute{ID: "../x"})
+	a.ErrorIs(err, errInvalidDisputeID)
+	entries, err := os.ReadDir(dir)
+	a.NoError(err)
+	a.Empty(entries)
+}
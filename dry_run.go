@@ -0,0 +1,285 @@
+package creemio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DryRunHeader is set on responses synthesized in dry-run mode.
+const DryRunHeader = "X-Creemio-Dry-Run"
+
+// DryRunID is used as the ID of objects that would have been created.
+const DryRunID = "dry_run"
+
+// simulate logs the mutation instead of sending it and returns a response
+// synthesized from the current state of the object.
+func (c *Client) simulate(req *http.Request, m mutation) (*http.Response, error) {
+	var payload []byte
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.dryRunLogger.InfoContext(req.Context(), "creemio dry run",
+		"service", m.service,
+		"action", m.action,
+		"method", req.Method,
+		"url", req.URL.String(),
+		"payload", string(payload),
+	)
+
+	obj, err := m.simulate(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+			DryRunHeader:   []string{"true"},
+		},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (s *CheckoutService) simulateCreate(ctx context.Context, data *CheckoutCreateRequest) (any, error) {
+	product, _, err := s.client.Products.Get(ctx, data.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkout := &Checkout{
+		ID:           DryRunID,
+		Mode:         s.client.mode,
+		Object:       "checkout",
+		Status:       "pending",
+		RequestID:    data.RequestID,
+		Product:      product,
+		Units:        data.Units,
+		CustomFields: data.CustomField,
+		SuccessURL:   data.SuccessURL,
+		Metadata:     data.Metadata,
+	}
+	if data.Customer != nil {
+		checkout.Customer = &Customer{ID: data.Customer.ID, Email: data.Customer.Email}
+	}
+	return checkout, nil
+}
+
+func (s *ProductService) simulateCreate(data *CreateProductRequest) any {
+	now := time.Now().UTC()
+	return &Product{
+		ID:                DryRunID,
+		Mode:              s.client.mode,
+		Object:            "product",
+		Name:              data.Name,
+		Description:       data.Description,
+		ImageURL:          data.ImageURL,
+		Price:             data.Price,
+		Currency:          data.Currency,
+		BillingType:       data.BillingType,
+		BillingPeriod:     data.BillingPeriod,
+		Status:            "active",
+		TaxMode:           data.TaxMode,
+		TaxCategory:       data.TaxCategory,
+		DefaultSuccessURL: data.DefaultSuccessURL,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+}
+
+func (s *DiscountService) simulateCreate(data *CreateDiscountRequest) any {
+	return &Discount{
+		ID:                DryRunID,
+		Mode:              s.client.mode,
+		Object:            "discount",
+		Status:            DiscountStatusActive,
+		Name:              data.Name,
+		Code:              data.Code,
+		Type:              data.Type,
+		Amount:            data.Amount,
+		Currency:          data.Currency,
+		Percentage:        data.Percentage,
+		ExpiryDate:        data.ExpiryDate,
+		MaxRedemptions:    data.MaxRedemptions,
+		Duration:          data.Duration,
+		DurationInMonths:  data.DurationInMonths,
+		AppliesToProducts: data.AppliesToProducts,
+	}
+}
+
+func (s *DiscountService) simulateDelete(ctx context.Context, id string) (any, error) {
+	discount, _, err := s.Get(ctx, &DiscountRequestQuery{DiscountID: id})
+	return discount, err
+}
+
+func (s *DiscountService) simulateUpdate(ctx context.Context, data *UpdateDiscountRequest) (any, error) {
+	discount, _, err := s.Get(ctx, &DiscountRequestQuery{DiscountID: data.DiscountID})
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Name) > 0 {
+		discount.Name = data.Name
+	}
+	if data.ExpiryDate != nil {
+		discount.ExpiryDate = data.ExpiryDate
+	}
+	if data.MaxRedemptions > 0 {
+		discount.MaxRedemptions = data.MaxRedemptions
+	}
+	if len(data.AppliesToProducts) > 0 {
+		discount.AppliesToProducts = data.AppliesToProducts
+	}
+	return discount, nil
+}
+
+func (s *CustomerService) simulateCreate(data *CreateCustomerRequest) any {
+	now := time.Now().UTC()
+	return &Customer{
+		ID:        DryRunID,
+		Mode:      s.client.mode,
+		Object:    "customer",
+		Email:     data.Email,
+		Name:      data.Name,
+		Country:   data.Country,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (s *CustomerService) simulateUpdate(ctx context.Context, data *UpdateCustomerRequest) (any, error) {
+	customer, _, err := s.Get(ctx, &CustomerRequestQuery{ID: data.CustomerID})
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Name) > 0 {
+		customer.Name = data.Name
+	}
+	if len(data.Country) > 0 {
+		customer.Country = data.Country
+	}
+	customer.UpdatedAt = time.Now().UTC()
+	return customer, nil
+}
+
+func (s *LicenseService) simulateActivate(data *LicenseActivateRequest) any {
+	now := time.Now().UTC()
+	return &License{
+		ID:        DryRunID,
+		Mode:      s.client.mode,
+		Object:    "license",
+		Status:    string(LicenseStatusActive),
+		Key:       data.Key,
+		CreatedAt: now,
+		Instance: &LicenseInstance{
+			ID:        DryRunID,
+			Mode:      s.client.mode,
+			Object:    "license-instance",
+			Name:      data.InstanceName,
+			Status:    string(LicenseStatusActive),
+			CreatedAt: now,
+		},
+	}
+}
+
+func (s *LicenseService) simulateDeactivate(ctx context.Context, data *LicenseDeactivateRequest) (any, error) {
+	license, _, err := s.Validate(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if license.Activation > 0 {
+		license.Activation--
+	}
+	if license.Instance != nil {
+		license.Instance.Status = "deactivated"
+	}
+	return license, nil
+}
+
+func (s *SubscriptionService) simulateUpdate(ctx context.Context, data *UpdateSubscriptionRequest) (any, error) {
+	sub, _, err := s.Get(ctx, data.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data.Items) > 0 {
+		sub.Items = data.Items
+	}
+	sub.UpdatedAt = time.Now().UTC()
+	return sub, nil
+}
+
+func (s *SubscriptionService) simulateUpgrade(ctx context.Context, data *UpgradeSubscriptionRequest) (any, error) {
+	sub, _, err := s.Get(ctx, data.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	product, _, err := s.client.Products.Get(ctx, data.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.Product = product
+	sub.UpdatedAt = time.Now().UTC()
+	return sub, nil
+}
+
+func (s *SubscriptionService) simulateStatus(ctx context.Context, id string, status SubscriptionStatus) (any, error) {
+	sub, _, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	sub.Status = status
+	sub.UpdatedAt = now
+	if status == SubscriptionStatusCanceled {
+		sub.CanceledAt = &now
+	}
+	return sub, nil
+}
+
+func (s *RefundService) simulateCreate(ctx context.Context, data *CreateRefundRequest) (any, error) {
+	transaction, _, err := s.client.Transactions.Get(ctx, data.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := data.Amount
+	if amount == 0 {
+		amount = transaction.AmountPaid - transaction.RefundedAmount
+	}
+	return &Refund{
+		ID:             DryRunID,
+		Object:         "refund",
+		Status:         "pending",
+		RefundAmount:   amount,
+		RefundCurrency: transaction.Currency,
+		Reason:         data.Reason,
+		Transaction:    transaction,
+		CreatedAt:      time.Now().UnixMilli(),
+		Mode:           transaction.Mode,
+	}, nil
+}
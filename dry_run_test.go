@@ -0,0 +1,137 @@
+package creemio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newDryRunServer serves the read endpoints used to synthesize responses and
+// fails the test on anything else.
+func newDryRunServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET /%s/subscriptions", APIVersion), mock.HandleGetSubscription)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/products", APIVersion), mock.HandleGetProduct)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/discounts", APIVersion), mock.HandleGetDiscount)
+	mux.HandleFunc(fmt.Sprintf("POST /%s/licenses/validate", APIVersion), mock.HandlePostValidateLicense)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in dry-run mode: %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestDryRun_SubscriptionCancel(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := newDryRunServer(t)
+	defer s.Close()
+
+	var logs bytes.Buffer
+	c := New(
+		WithBaseURL(s.URL),
+		WithMode(ModeProduction),
+		WithProductionGuard(),
+		WithDryRun(slog.New(slog.NewJSONHandler(&logs, nil))),
+	)
+
+	resp, res, err := c.Subscriptions.Cancel(context.Background(), "sub_abc123")
+
+	a.NoError(err)
+	a.Equal(http.StatusOK, res.Status)
+	a.Equal("true", res.Headers.Get(DryRunHeader))
+	a.Equal(fmt.Sprintf("/%s/subscriptions/sub_abc123/cancel", APIVersion), res.RequestURL.RequestURI())
+	a.Equal("sub_abc123", resp.ID)
+	a.Equal(SubscriptionStatusCanceled, resp.Status)
+	a.NotNil(resp.CanceledAt)
+
+	var entry map[string]any
+	a.NoError(json.Unmarshal(logs.Bytes(), &entry))
+	a.Equal("subscriptions", entry["service"])
+	a.Equal("cancel", entry["action"])
+	a.Equal(http.MethodPost, entry["method"])
+	a.Equal(s.URL+"/v1/subscriptions/sub_abc123/cancel", entry["url"])
+}
+
+func TestDryRun_SubscriptionUpgrade(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := newDryRunServer(t)
+	defer s.Close()
+
+	var logs bytes.Buffer
+	c := New(
+		WithBaseURL(s.URL),
+		WithDryRun(slog.New(slog.NewJSONHandler(&logs, nil))),
+	)
+
+	resp, _, err := c.Subscriptions.Upgrade(context.Background(), &UpgradeSubscriptionRequest{
+		SubscriptionID: "sub_abc123",
+		ProductID:      "prod_123",
+		UpdateBehavior: ProrationChargeImmediately,
+	})
+
+	a.NoError(err)
+	a.Equal("sub_abc123", resp.ID)
+
+	var expected Product
+	a.NoError(json.Unmarshal(mock.GetProductResponse(), &expected))
+	a.Equal(expected.ID, resp.Product.ID)
+
+	var entry map[string]any
+	a.NoError(json.Unmarshal(logs.Bytes(), &entry))
+	a.JSONEq(`{"product_id": "prod_123", "update_behavior": "proration-charge-immediately"}`, entry["payload"].(string))
+}
+
+func TestDryRun_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := newDryRunServer(t)
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithDryRun(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	)
+
+	discount, _, err := c.Discounts.Create(context.Background(), &CreateDiscountRequest{
+		Name:              "KING100",
+		Code:              "KING100",
+		Type:              DiscountTypePercentage,
+		Percentage:        100,
+		Duration:          DiscountDurationOnce,
+		AppliesToProducts: []string{"prod_1"},
+	})
+	a.NoError(err)
+	a.Equal(DryRunID, discount.ID)
+	a.Equal("KING100", discount.Code)
+
+	checkout, _, err := c.Checkouts.Create(context.Background(), &CheckoutCreateRequest{
+		ProductID: "prod_123",
+		Metadata:  map[string]any{"userID": "user_1"},
+	})
+	a.NoError(err)
+	a.Equal(DryRunID, checkout.ID)
+	a.Equal("pending", checkout.Status)
+	a.Equal("user_1", checkout.Metadata["userID"])
+
+	license, _, err := c.Licenses.Deactivate(context.Background(), &LicenseDeactivateRequest{
+		Key:        "ABC123-XYZ456-XYZ456-XYZ456",
+		InstanceID: "inst_456xyz",
+	})
+	a.NoError(err)
+	a.Equal(4, license.Activation)
+	a.Equal("deactivated", license.Instance.Status)
+}
@@ -0,0 +1,95 @@
+package creemio
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+const (
+	// TestAPIKeyPrefix is the prefix of API keys issued for test mode.
+	TestAPIKeyPrefix = "creem_test_"
+	// APIKeyPrefix is the prefix shared by all creemio API keys.
+	APIKeyPrefix = "creem_"
+)
+
+type allowProductionKey struct{}
+
+// AllowProductionMutations returns a context that lets calls made with it
+// through the production guard enabled by WithProductionGuard.
+func AllowProductionMutations(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowProductionKey{}, true)
+}
+
+func baseURLForKey(key string) string {
+	if strings.HasPrefix(key, TestAPIKeyPrefix) {
+		return TestAPIURL
+	}
+	return APIURL
+}
+
+// detectMode works out the environment from the base URL, falling back to the
+// API key prefix for custom base URLs.
+func detectMode(baseURL, key string) Mode {
+	switch strings.TrimSuffix(baseURL, "/") {
+	case TestAPIURL:
+		return ModeTest
+	case APIURL:
+		return ModeProduction
+	}
+
+	switch {
+	case strings.HasPrefix(key, TestAPIKeyPrefix):
+		return ModeTest
+	case strings.HasPrefix(key, APIKeyPrefix):
+		return ModeProduction
+	}
+	return ""
+}
+
+// modeMatches reports whether a response in mode got is acceptable for a
+// client expecting mode expected. Objects without a mode always match.
+func modeMatches(expected, got Mode) bool {
+	if len(expected) == 0 || len(got) == 0 {
+		return true
+	}
+	if expected == ModeProduction {
+		return got == ModeProduction
+	}
+	return got != ModeProduction
+}
+
+// checkMode inspects the mode of a response body, for both single objects
+// and paginated lists.
+func (c *Client) checkMode(body []byte) error {
+	var obj struct {
+		Mode  Mode `json:"mode"`
+		Items []struct {
+			Mode Mode `json:"mode"`
+		} `json:"items"`
+	}
+	// Non object responses have no mode to check.
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil
+	}
+
+	if !modeMatches(c.mode, obj.Mode) {
+		return &ModeMismatchError{Expected: c.mode, Got: obj.Mode}
+	}
+	for _, item := range obj.Items {
+		if !modeMatches(c.mode, item.Mode) {
+			return &ModeMismatchError{Expected: c.mode, Got: item.Mode}
+		}
+	}
+	return nil
+}
+
+func (c *Client) checkProductionGuard(ctx context.Context, m mutation) error {
+	if !c.productionGuard || !m.guarded || c.mode != ModeProduction {
+		return nil
+	}
+	if allowed, _ := ctx.Value(allowProductionKey{}).(bool); allowed {
+		return nil
+	}
+	return ErrProductionMutation
+}
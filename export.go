@@ -0,0 +1,388 @@
+package creemio
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ExportKindTransaction = "transaction"
+	ExportKindRefund      = "refund"
+)
+
+// ExportRecord is a transaction or refund flattened for export. Amounts are
+// in the smallest currency unit.
+type ExportRecord struct {
+	Kind           string
+	ID             string
+	Time           time.Time
+	Status         string
+	Type           string
+	Currency       string
+	Amount         int
+	AmountPaid     int
+	TaxAmount      int
+	TaxCountry     string
+	DiscountAmount int
+	RefundedAmount int
+	Customer       string
+	Subscription   string
+	Order          string
+	// TransactionID is the refunded transaction of a refund.
+	TransactionID string
+	Description   string
+	Reason        string
+}
+
+func transactionRecord(t *Transaction) ExportRecord {
+	return ExportRecord{
+		Kind:           ExportKindTransaction,
+		ID:             t.ID,
+		Time:           t.CreatedTime().UTC(),
+		Status:         string(t.Status),
+		Type:           string(t.Type),
+		Currency:       t.Currency,
+		Amount:         t.Amount,
+		AmountPaid:     t.AmountPaid,
+		TaxAmount:      t.TaxAmount,
+		TaxCountry:     t.TaxCountry,
+		DiscountAmount: t.DiscountAmount,
+		RefundedAmount: t.RefundedAmount,
+		Customer:       t.Customer,
+		Subscription:   t.Subscription,
+		Order:          t.Order,
+		Description:    t.Description,
+	}
+}
+
+func refundRecord(r *Refund) ExportRecord {
+	record := ExportRecord{
+		Kind:           ExportKindRefund,
+		ID:             r.ID,
+		Time:           time.UnixMilli(r.CreatedAt).UTC(),
+		Status:         r.Status,
+		Currency:       r.RefundCurrency,
+		Amount:         r.RefundAmount,
+		RefundedAmount: r.RefundAmount,
+		Reason:         r.Reason,
+	}
+	if r.Transaction != nil {
+		record.TransactionID = r.Transaction.ID
+	}
+	if r.Customer != nil {
+		record.Customer = r.Customer.ID
+	}
+	if r.Subscription != nil {
+		record.Subscription = r.Subscription.ID
+	}
+	if r.Order != nil {
+		record.Order = r.Order.ID
+	}
+	return record
+}
+
+type ExportQuery struct {
+	// CustomerID, the creation time range and Currency filter as in
+	// TransactionListQuery.
+	CustomerID    string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Currency      string
+	// Refunds adds the refunds in the same range after the transactions.
+	Refunds bool
+}
+
+// Export iterates over the transactions, and optionally refunds, matching
+// query on every page. Iteration stops at the first error.
+func (s *TransactionService) Export(ctx context.Context, query *ExportQuery) iter.Seq2[ExportRecord, error] {
+	q := ExportQuery{}
+	if query != nil {
+		q = *query
+	}
+
+	return func(yield func(ExportRecord, error) bool) {
+		transactions := s.All(ctx, &TransactionListQuery{
+			CustomerID:    q.CustomerID,
+			Currency:      q.Currency,
+			CreatedAfter:  q.CreatedAfter,
+			CreatedBefore: q.CreatedBefore,
+		})
+		for t, err := range transactions {
+			if err != nil {
+				yield(ExportRecord{}, err)
+				return
+			}
+			if !yield(transactionRecord(&t), nil) {
+				return
+			}
+		}
+		if !q.Refunds {
+			return
+		}
+
+		refunds := s.client.Refunds.All(ctx, &RefundListQuery{
+			CustomerID:    q.CustomerID,
+			CreatedAfter:  q.CreatedAfter,
+			CreatedBefore: q.CreatedBefore,
+		})
+		for r, err := range refunds {
+			if err != nil {
+				yield(ExportRecord{}, err)
+				return
+			}
+			if len(q.Currency) > 0 && !strings.EqualFold(r.RefundCurrency, q.Currency) {
+				continue
+			}
+			if !yield(refundRecord(&r), nil) {
+				return
+			}
+		}
+	}
+}
+
+// ExportColumns maps the CSV column names to their values.
+var ExportColumns = map[string]func(*ExportRecord) string{
+	"kind":            func(r *ExportRecord) string { return r.Kind },
+	"id":              func(r *ExportRecord) string { return r.ID },
+	"date":            func(r *ExportRecord) string { return r.Time.Format(time.DateOnly) },
+	"time":            func(r *ExportRecord) string { return r.Time.Format(time.RFC3339) },
+	"status":          func(r *ExportRecord) string { return r.Status },
+	"type":            func(r *ExportRecord) string { return r.Type },
+	"currency":        func(r *ExportRecord) string { return r.Currency },
+	"amount":          func(r *ExportRecord) string { return FormatAmount(r.Amount, r.Currency) },
+	"amount_paid":     func(r *ExportRecord) string { return FormatAmount(r.AmountPaid, r.Currency) },
+	"tax_amount":      func(r *ExportRecord) string { return FormatAmount(r.TaxAmount, r.Currency) },
+	"tax_country":     func(r *ExportRecord) string { return r.TaxCountry },
+	"discount_amount": func(r *ExportRecord) string { return FormatAmount(r.DiscountAmount, r.Currency) },
+	"refunded_amount": func(r *ExportRecord) string { return FormatAmount(r.RefundedAmount, r.Currency) },
+	"customer":        func(r *ExportRecord) string { return r.Customer },
+	"subscription":    func(r *ExportRecord) string { return r.Subscription },
+	"order":           func(r *ExportRecord) string { return r.Order },
+	"transaction_id":  func(r *ExportRecord) string { return r.TransactionID },
+	"description":     func(r *ExportRecord) string { return r.Description },
+	"reason":          func(r *ExportRecord) string { return r.Reason },
+}
+
+// DefaultExportColumns are written by WriteExportCSV when no columns are
+// given.
+var DefaultExportColumns = []string{
+	"kind", "id", "date", "status", "type", "currency", "amount", "amount_paid",
+	"tax_amount", "tax_country", "discount_amount", "refunded_amount",
+	"customer", "subscription", "transaction_id", "description", "reason",
+}
+
+// WriteExportCSV writes records as CSV with the given columns, see
+// ExportColumns. Records are written as they arrive, so large exports are
+// not held in memory.
+func WriteExportCSV(w io.Writer, records iter.Seq2[ExportRecord, error], columns ...string) error {
+	if len(columns) == 0 {
+		columns = DefaultExportColumns
+	}
+	values := make([]func(*ExportRecord) string, len(columns))
+	for i, col := range columns {
+		v, ok := ExportColumns[col]
+		if !ok {
+			return fmt.Errorf("export: unknown column %q", col)
+		}
+		values[i] = v
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	row := make([]string, len(columns))
+	for r, err := range records {
+		if err != nil {
+			return err
+		}
+		for i, v := range values {
+			row[i] = v(&r)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// zeroDecimalCurrencies have no minor unit.
+var zeroDecimalCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true,
+	"KMF": true, "KRW": true, "MGA": true, "PYG": true, "RWF": true,
+	"UGX": true, "VND": true, "VUV": true, "XAF": true, "XOF": true,
+	"XPF": true,
+}
+
+// FormatAmount formats an amount in the smallest currency unit as a decimal,
+// e.g. 1999 USD as "19.99".
+func FormatAmount(amount int, currency string) string {
+	if zeroDecimalCurrencies[strings.ToUpper(currency)] {
+		return strconv.Itoa(amount)
+	}
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+type LedgerFormat string
+
+const (
+	// LedgerFormatLedger is the plain-text format of ledger-cli and hledger.
+	LedgerFormatLedger    LedgerFormat = "ledger"
+	LedgerFormatBeancount LedgerFormat = "beancount"
+)
+
+// LedgerAccounts are the accounts the parts of a transaction are posted to.
+type LedgerAccounts struct {
+	// Payments receives the amount paid, e.g. the Creem balance.
+	Payments string
+	// Revenue is credited with the price before discounts, excluding tax.
+	Revenue   string
+	Tax       string
+	Discounts string
+	Refunds   string
+	// Payee is the payee of every entry.
+	Payee string
+}
+
+var DefaultLedgerAccounts = LedgerAccounts{
+	Payments:  "Assets:Creem",
+	Revenue:   "Income:Sales",
+	Tax:       "Liabilities:SalesTax",
+	Discounts: "Expenses:Discounts",
+	Refunds:   "Expenses:Refunds",
+	Payee:     "Creem",
+}
+
+type ledgerPosting struct {
+	account string
+	amount  int
+}
+
+// WriteLedger writes every transaction as a balanced double-entry
+// transaction. The amount paid is posted to Payments, discounts and tax to
+// their accounts and Revenue balances the entry. Refunded amounts move from
+// Payments to Refunds within the same entry, so refund records are skipped
+// to avoid counting them twice. TaxCountry and the IDs are kept as metadata.
+// Beancount output opens the accounts on the date of the earliest entry, so
+// the entries are only written once every record has been read.
+func WriteLedger(w io.Writer, records iter.Seq2[ExportRecord, error], format LedgerFormat, accounts LedgerAccounts) error {
+	if format != LedgerFormatLedger && format != LedgerFormatBeancount {
+		return fmt.Errorf("export: unknown ledger format %q", format)
+	}
+
+	var (
+		n int
+		// Beancount entries are written after the open directives.
+		entries    []string
+		earliest   time.Time
+		currencies []string
+	)
+	for r, err := range records {
+		if err != nil {
+			return err
+		}
+		if r.Kind != ExportKindTransaction {
+			continue
+		}
+
+		postings := []ledgerPosting{
+			{accounts.Payments, r.AmountPaid},
+			{accounts.Discounts, r.DiscountAmount},
+			{accounts.Tax, -r.TaxAmount},
+			{accounts.Revenue, -(r.AmountPaid + r.DiscountAmount - r.TaxAmount)},
+		}
+		if r.RefundedAmount > 0 {
+			postings = append(postings,
+				ledgerPosting{accounts.Refunds, r.RefundedAmount},
+				ledgerPosting{accounts.Payments, -r.RefundedAmount},
+			)
+		}
+
+		meta := [][2]string{{"creem_id", r.ID}}
+		for _, m := range [][2]string{{"customer", r.Customer}, {"subscription", r.Subscription}, {"tax_country", r.TaxCountry}} {
+			if len(m[1]) > 0 {
+				meta = append(meta, m)
+			}
+		}
+
+		description := r.Description
+		if len(description) == 0 {
+			description = r.ID
+		}
+		currency := strings.ToUpper(r.Currency)
+		if !slices.Contains(currencies, currency) {
+			currencies = append(currencies, currency)
+		}
+		if n == 0 || r.Time.Before(earliest) {
+			earliest = r.Time
+		}
+
+		var b strings.Builder
+		if n > 0 {
+			b.WriteByte('\n')
+		}
+		n++
+
+		switch format {
+		case LedgerFormatBeancount:
+			fmt.Fprintf(&b, "%s * %s %s\n", r.Time.Format(time.DateOnly), strconv.Quote(accounts.Payee), strconv.Quote(description))
+			for _, m := range meta {
+				fmt.Fprintf(&b, "  %s: %s\n", m[0], strconv.Quote(m[1]))
+			}
+		case LedgerFormatLedger:
+			fmt.Fprintf(&b, "%s * %s  ; %s\n", r.Time.Format("2006/01/02"), accounts.Payee, description)
+			for _, m := range meta {
+				fmt.Fprintf(&b, "    ; %s: %s\n", m[0], m[1])
+			}
+		}
+		for _, p := range postings {
+			if p.amount == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "  %-40s %12s %s\n", p.account, FormatAmount(p.amount, currency), currency)
+		}
+
+		if format == LedgerFormatBeancount {
+			entries = append(entries, b.String())
+			continue
+		}
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	if format != LedgerFormatBeancount || n == 0 {
+		return nil
+	}
+
+	slices.Sort(currencies)
+	var (
+		b      strings.Builder
+		opened []string
+	)
+	for _, account := range []string{accounts.Payments, accounts.Revenue, accounts.Tax, accounts.Discounts, accounts.Refunds} {
+		if slices.Contains(opened, account) {
+			continue
+		}
+		opened = append(opened, account)
+		fmt.Fprintf(&b, "%s open %s %s\n", earliest.Format(time.DateOnly), account, strings.Join(currencies, ","))
+	}
+	b.WriteByte('\n')
+	for _, entry := range entries {
+		b.WriteString(entry)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
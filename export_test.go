@@ -0,0 +1,163 @@
+package creemio
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func exportRecords(records ...ExportRecord) iter.Seq2[ExportRecord, error] {
+	return func(yield func(ExportRecord, error) bool) {
+		for _, r := range records {
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestTransactions_Export(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET /%s/transactions/search", APIVersion), mock.HandleGetTransactionList)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/refunds/search", APIVersion), mock.HandleGetRefundList)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+
+	var kinds []string
+	for r, err := range c.Transactions.Export(context.Background(), &ExportQuery{Refunds: true}) {
+		a.NoError(err)
+		kinds = append(kinds, r.Kind)
+	}
+	a.Equal([]string{ExportKindTransaction, ExportKindTransaction, ExportKindRefund, ExportKindRefund}, kinds)
+
+	var buf bytes.Buffer
+	err := WriteExportCSV(&buf, c.Transactions.Export(context.Background(), &ExportQuery{Refunds: true}), "kind", "id", "amount", "refunded_amount")
+	a.NoError(err)
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	a.NoError(err)
+	a.Len(rows, 5)
+	a.Equal([]string{"kind", "id", "amount", "refunded_amount"}, rows[0])
+	a.Equal([]string{ExportKindTransaction, "49.00"}, []string{rows[1][0], rows[1][2]})
+	a.Equal([]string{ExportKindRefund, "15.00", "15.00"}, []string{rows[3][0], rows[3][2], rows[3][3]})
+}
+
+func TestWriteExportCSV_UnknownColumn(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var buf bytes.Buffer
+	err := WriteExportCSV(&buf, exportRecords(), "id", "price")
+	a.EqualError(err, `export: unknown column "price"`)
+	a.Zero(buf.Len())
+}
+
+func TestFormatAmount(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.Equal("19.99", FormatAmount(1999, "USD"))
+	a.Equal("0.05", FormatAmount(5, "eur"))
+	a.Equal("-1.50", FormatAmount(-150, "USD"))
+	a.Equal("1500", FormatAmount(1500, "JPY"))
+}
+
+func TestWriteLedger(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	records := exportRecords(
+		ExportRecord{
+			Kind:        ExportKindTransaction,
+			ID:          "txn_2",
+			Time:        time.Date(2024, 10, 20, 9, 0, 0, 0, time.UTC),
+			Currency:    "EUR",
+			Amount:      900,
+			AmountPaid:  900,
+			Description: "Basic plan",
+		},
+		ExportRecord{
+			Kind:           ExportKindTransaction,
+			ID:             "txn_1",
+			Time:           time.Date(2024, 10, 12, 9, 0, 0, 0, time.UTC),
+			Currency:       "USD",
+			Amount:         3000,
+			AmountPaid:     2800,
+			TaxAmount:      400,
+			TaxCountry:     "DE",
+			DiscountAmount: 600,
+			RefundedAmount: 1000,
+			Customer:       "cust_1",
+			Description:    "Pro plan",
+		},
+		// Refunds are already part of their transaction.
+		ExportRecord{Kind: ExportKindRefund, ID: "ref_1", Currency: "USD", RefundedAmount: 1000},
+	)
+
+	var buf bytes.Buffer
+	a.NoError(WriteLedger(&buf, records, LedgerFormatLedger, DefaultLedgerAccounts))
+	out := buf.String()
+	a.True(strings.HasPrefix(out, "2024/10/20 * Creem  ; Basic plan\n"))
+	a.Contains(out, "\n\n2024/10/12 * Creem  ; Pro plan\n")
+	a.Contains(out, "    ; creem_id: txn_1\n")
+	a.Contains(out, "    ; tax_country: DE\n")
+	a.NotContains(out, "ref_1")
+	a.Equal(0, ledgerBalance(t, out))
+
+	buf.Reset()
+	a.NoError(WriteLedger(&buf, records, LedgerFormatBeancount, DefaultLedgerAccounts))
+	out = buf.String()
+	// Every account is opened before the earliest entry.
+	a.True(strings.HasPrefix(out, "2024-10-12 open Assets:Creem EUR,USD\n"))
+	for _, account := range []string{"Income:Sales", "Liabilities:SalesTax", "Expenses:Discounts", "Expenses:Refunds"} {
+		a.Contains(out, "2024-10-12 open "+account+" EUR,USD\n")
+	}
+	a.Contains(out, "\n\n"+`2024-10-20 * "Creem" "Basic plan"`+"\n")
+	a.Contains(out, `2024-10-12 * "Creem" "Pro plan"`+"\n")
+	a.Contains(out, `  tax_country: "DE"`+"\n")
+	a.Equal(0, ledgerBalance(t, out))
+
+	buf.Reset()
+	a.NoError(WriteLedger(&buf, exportRecords(), LedgerFormatBeancount, DefaultLedgerAccounts))
+	a.Empty(buf.String())
+
+	a.Error(WriteLedger(&buf, records, "gnucash", DefaultLedgerAccounts))
+}
+
+// ledgerBalance sums the posting amounts of out in cents.
+func ledgerBalance(t *testing.T, out string) int {
+	t.Helper()
+
+	sum := 0
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || !strings.Contains(fields[0], ":") {
+			continue
+		}
+		var units, cents int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(fields[1], "-"), "%d.%d", &units, &cents); err != nil {
+			t.Fatal(err)
+		}
+		amount := units*100 + cents
+		if strings.HasPrefix(fields[1], "-") {
+			amount = -amount
+		}
+		sum += amount
+	}
+	return sum
+}
@@ -0,0 +1,355 @@
+// Package exporter exposes Creem business metrics and SDK request counters
+// in the Prometheus text exposition format.
+package exporter
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go"
+)
+
+const (
+	DefaultNamespace    = "creem"
+	DefaultPollInterval = time.Minute
+)
+
+// DefaultCurrencies are polled unless Config says otherwise.
+var DefaultCurrencies = []creemio.Currency{creemio.CurrencyEUR, creemio.CurrencyUSD}
+
+type Config struct {
+	Client *creemio.Client
+	// Currencies are polled separately, as the stats endpoint reports one
+	// currency at a time.
+	Currencies []creemio.Currency
+	// PollInterval is the time between two polls.
+	PollInterval time.Duration
+	// PeriodInterval is the bucket size of the latest period, the API
+	// default when empty.
+	PeriodInterval creemio.Interval
+	// Namespace prefixes every metric name.
+	Namespace string
+	// Requests, if set, are exported along with the business metrics.
+	Requests *RequestCounter
+	// Logger receives failed polls, slog.Default when nil.
+	Logger *slog.Logger
+}
+
+type scrape struct {
+	summary *creemio.MetricsSummary
+	success bool
+	at      time.Time
+}
+
+type requestKey struct {
+	resource string
+	method   string
+	code     string
+}
+
+type requestStats struct {
+	count    uint64
+	errors   uint64
+	duration float64
+}
+
+// RequestCounter counts the requests the SDK sends. The zero value is
+// ready to use.
+type RequestCounter struct {
+	mu       sync.Mutex
+	requests map[requestKey]*requestStats
+}
+
+// Exporter polls the stats endpoint and serves the last results on
+// /metrics. It is safe for concurrent use.
+type Exporter struct {
+	cfg Config
+
+	mu      sync.RWMutex
+	scrapes map[creemio.Currency]*scrape
+}
+
+func New(cfg Config) *Exporter {
+	if len(cfg.Currencies) == 0 {
+		cfg.Currencies = DefaultCurrencies
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if len(cfg.Namespace) == 0 {
+		cfg.Namespace = DefaultNamespace
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+
+	return &Exporter{
+		cfg:     cfg,
+		scrapes: make(map[creemio.Currency]*scrape),
+	}
+}
+
+// Poll fetches the summary of every currency concurrently. A failed
+// currency keeps its last summary and is reported as unsuccessful.
+func (e *Exporter) Poll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, currency := range e.cfg.Currencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			summary, _, err := e.cfg.Client.Stats.GetMetricsSummary(ctx, &creemio.MetricsSummaryQuery{
+				Currency: currency,
+				Interval: e.cfg.PeriodInterval,
+			})
+			if err != nil {
+				e.cfg.Logger.Error("exporter: poll failed", "currency", currency, "error", err)
+			}
+
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			s, ok := e.scrapes[currency]
+			if !ok {
+				s = &scrape{}
+				e.scrapes[currency] = s
+			}
+			s.success = err == nil
+			s.at = time.Now()
+			if err == nil {
+				s.summary = summary
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Run polls immediately and then every PollInterval until ctx is done.
+func (e *Exporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		e.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Transport counts the requests sent through next, http.DefaultTransport
+// when nil. Pass it to the client with creemio.WithHTTPClient.
+func (c *RequestCounter) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		res, err := next.RoundTrip(req)
+
+		key := requestKey{resource: resource(req.URL.Path), method: req.Method}
+		failed := err != nil
+		if err == nil {
+			key.code = strconv.Itoa(res.StatusCode)
+			failed = res.StatusCode >= 400
+		}
+
+		c.mu.Lock()
+		if c.requests == nil {
+			c.requests = make(map[requestKey]*requestStats)
+		}
+		stats, ok := c.requests[key]
+		if !ok {
+			stats = &requestStats{}
+			c.requests[key] = stats
+		}
+		stats.count++
+		stats.duration += time.Since(start).Seconds()
+		if failed {
+			stats.errors++
+		}
+		c.mu.Unlock()
+
+		return res, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// resource returns the first path segment after the API version, e.g.
+// "subscriptions" for /v1/subscriptions/sub_1/cancel.
+func resource(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) > 1 && parts[0] == creemio.APIVersion {
+		return parts[1]
+	}
+	return "unknown"
+}
+
+// ServeHTTP writes the metrics in the text exposition format.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := e.WriteMetrics(w); err != nil {
+		e.cfg.Logger.Error("exporter: write failed", "error", err)
+	}
+}
+
+type sample struct {
+	labels []string
+	value  float64
+}
+
+type metric struct {
+	name    string
+	help    string
+	kind    string
+	samples []sample
+}
+
+var totals = []struct {
+	name  string
+	help  string
+	value func(*creemio.Totals) float64
+}{
+	{"products", "Number of products.", func(t *creemio.Totals) float64 { return float64(t.TotalProducts) }},
+	{"subscriptions", "Number of subscriptions.", func(t *creemio.Totals) float64 { return float64(t.TotalSubscriptions) }},
+	{"active_subscriptions", "Number of active subscriptions.", func(t *creemio.Totals) float64 { return float64(t.ActiveSubscriptions) }},
+	{"customers", "Number of customers.", func(t *creemio.Totals) float64 { return float64(t.TotalCustomers) }},
+	{"payments", "Number of payments.", func(t *creemio.Totals) float64 { return float64(t.TotalPayments) }},
+	{"revenue", "Total revenue in the smallest currency unit.", func(t *creemio.Totals) float64 { return t.TotalRevenue }},
+	{"net_revenue", "Total net revenue in the smallest currency unit.", func(t *creemio.Totals) float64 { return t.TotalNetRevenue }},
+	{"monthly_recurring_revenue", "Monthly recurring revenue in the smallest currency unit.", func(t *creemio.Totals) float64 { return t.MonthlyRecurringRevenue }},
+	{"net_monthly_recurring_revenue", "Net monthly recurring revenue in the smallest currency unit.", func(t *creemio.Totals) float64 { return t.NetMonthlyRecurringRevenue }},
+}
+
+func (e *Exporter) metrics() []metric {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	ns := e.cfg.Namespace
+	var metrics []metric
+
+	currencies := make([]creemio.Currency, 0, len(e.scrapes))
+	for currency := range e.scrapes {
+		currencies = append(currencies, currency)
+	}
+	slices.Sort(currencies)
+
+	for _, t := range totals {
+		m := metric{name: ns + "_" + t.name, help: t.help, kind: "gauge"}
+		for _, currency := range currencies {
+			if s := e.scrapes[currency]; s.summary != nil {
+				m.samples = append(m.samples, sample{[]string{"currency", string(currency)}, t.value(&s.summary.Totals)})
+			}
+		}
+		metrics = append(metrics, m)
+	}
+
+	gross := metric{name: ns + "_period_gross_revenue", help: "Gross revenue of the latest period in the smallest currency unit.", kind: "gauge"}
+	net := metric{name: ns + "_period_net_revenue", help: "Net revenue of the latest period in the smallest currency unit.", kind: "gauge"}
+	start := metric{name: ns + "_period_start_timestamp_seconds", help: "Start of the latest period.", kind: "gauge"}
+	success := metric{name: ns + "_poll_success", help: "Whether the last poll of the currency succeeded.", kind: "gauge"}
+	last := metric{name: ns + "_poll_timestamp_seconds", help: "Time of the last poll of the currency.", kind: "gauge"}
+	for _, currency := range currencies {
+		s := e.scrapes[currency]
+		labels := []string{"currency", string(currency)}
+
+		if s.summary != nil && len(s.summary.Periods) > 0 {
+			latest := slices.MaxFunc(s.summary.Periods, func(a, b creemio.Period) int {
+				return cmp.Compare(a.Timestamp, b.Timestamp)
+			})
+			gross.samples = append(gross.samples, sample{labels, latest.GrossRevenue})
+			net.samples = append(net.samples, sample{labels, latest.NetRevenue})
+			start.samples = append(start.samples, sample{labels, float64(latest.Timestamp) / 1000})
+		}
+
+		ok := 0.0
+		if s.success {
+			ok = 1
+		}
+		success.samples = append(success.samples, sample{labels, ok})
+		last.samples = append(last.samples, sample{labels, float64(s.at.UnixMilli()) / 1000})
+	}
+	metrics = append(metrics, gross, net, start, success, last)
+
+	if e.cfg.Requests != nil {
+		metrics = append(metrics, e.cfg.Requests.metrics(ns)...)
+	}
+
+	return metrics
+}
+
+func (c *RequestCounter) metrics(ns string) []metric {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]requestKey, 0, len(c.requests))
+	for key := range c.requests {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b requestKey) int {
+		return strings.Compare(a.resource+" "+a.method+" "+a.code, b.resource+" "+b.method+" "+b.code)
+	})
+
+	requests := metric{name: ns + "_sdk_requests_total", help: "Requests sent by the SDK.", kind: "counter"}
+	failures := metric{name: ns + "_sdk_request_errors_total", help: "Requests that failed or were answered with an error status.", kind: "counter"}
+	duration := metric{name: ns + "_sdk_request_duration_seconds_total", help: "Time spent on requests.", kind: "counter"}
+	for _, key := range keys {
+		stats := c.requests[key]
+		labels := []string{"resource", key.resource, "method", key.method, "code", key.code}
+		requests.samples = append(requests.samples, sample{labels, float64(stats.count)})
+		failures.samples = append(failures.samples, sample{labels, float64(stats.errors)})
+		duration.samples = append(duration.samples, sample{labels, stats.duration})
+	}
+	return []metric{requests, failures, duration}
+}
+
+// WriteMetrics writes the metrics in the text exposition format.
+func (e *Exporter) WriteMetrics(w io.Writer) error {
+	var b strings.Builder
+	for _, m := range e.metrics() {
+		if len(m.samples) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(&b, "# TYPE %s %s\n", m.name, m.kind)
+		for _, s := range m.samples {
+			b.WriteString(m.name)
+			if len(s.labels) > 0 {
+				b.WriteByte('{')
+				for i := 0; i < len(s.labels); i += 2 {
+					if i > 0 {
+						b.WriteByte(',')
+					}
+					fmt.Fprintf(&b, `%s="%s"`, s.labels[i], escapeLabel(s.labels[i+1]))
+				}
+				b.WriteByte('}')
+			}
+			b.WriteByte(' ')
+			b.WriteString(strconv.FormatFloat(s.value, 'f', -1, 64))
+			b.WriteByte('\n')
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
@@ -0,0 +1,113 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go"
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExporter(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET /%s/stats/summary", creemio.APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("currency") == "GBP" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "unsupported currency"}`))
+			return
+		}
+		mock.HandleGetMetricsSummary(w, r)
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	requests := &RequestCounter{}
+	c := creemio.New(
+		creemio.WithBaseURL(s.URL),
+		creemio.WithHTTPClient(&http.Client{Transport: requests.Transport(nil)}),
+	)
+	e := New(Config{
+		Client:     c,
+		Currencies: []creemio.Currency{creemio.CurrencyUSD, "GBP"},
+		Requests:   requests,
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	e.Poll(context.Background())
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	a.Equal(http.StatusOK, rec.Code)
+	a.Contains(rec.Header().Get("Content-Type"), "version=0.0.4")
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE creem_monthly_recurring_revenue gauge",
+		`creem_monthly_recurring_revenue{currency="USD"} 94200`,
+		`creem_active_subscriptions{currency="USD"} 21`,
+		`creem_poll_success{currency="GBP"} 0`,
+		`creem_poll_success{currency="USD"} 1`,
+		"# TYPE creem_sdk_requests_total counter",
+		`creem_sdk_requests_total{resource="stats",method="GET",code="200"} 1`,
+		`creem_sdk_request_errors_total{resource="stats",method="GET",code="400"} 1`,
+	} {
+		a.Contains(body, line+"\n")
+	}
+	// GBP never succeeded, so it has no totals.
+	a.NotContains(body, `creem_revenue{currency="GBP"}`)
+
+	// The latest period is the one with the highest timestamp.
+	var summary creemio.MetricsSummary
+	a.NoError(json.Unmarshal(mock.GetMetricsSummaryResponse(), &summary))
+	latest := summary.Periods[len(summary.Periods)-1]
+	a.Contains(body, fmt.Sprintf("creem_period_start_timestamp_seconds{currency=\"USD\"} %d\n", latest.Timestamp/1000))
+}
+
+func TestExporter_Run(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandleGetMetricsSummary))
+	defer s.Close()
+
+	e := New(Config{
+		Client:       creemio.New(creemio.WithBaseURL(s.URL)),
+		Currencies:   []creemio.Currency{creemio.CurrencyEUR},
+		PollInterval: 10 * time.Millisecond,
+		Namespace:    "shop",
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- e.Run(ctx) }()
+
+	var b strings.Builder
+	a.Eventually(func() bool {
+		b.Reset()
+		a.NoError(e.WriteMetrics(&b))
+		return strings.Contains(b.String(), `shop_customers{currency="EUR"} 35`)
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	a.ErrorIs(<-done, context.Canceled)
+	a.NotContains(b.String(), "sdk_requests_total")
+}
+
+func TestEscapeLabel(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.Equal(`a\"b\\c\nd`, escapeLabel("a\"b\\c\nd"))
+}
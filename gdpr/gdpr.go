@@ -0,0 +1,236 @@
+// Package gdpr answers data subject requests: it exports everything Creem
+// holds about a customer and lists what has to be erased.
+package gdpr
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go"
+)
+
+var errNoEmail = errors.New("gdpr: email is required")
+
+// Via is where a checklist item has to be carried out.
+type Via string
+
+const (
+	// ViaAPI items are carried out by Erase.
+	ViaAPI       Via = "api"
+	ViaDashboard Via = "dashboard"
+	// ViaRetain items are kept to meet legal obligations, such as tax
+	// records, and have to be mentioned in the answer.
+	ViaRetain Via = "retain"
+)
+
+// Item is a step of the erasure checklist.
+type Item struct {
+	Object string `json:"object"`
+	ID     string `json:"id"`
+	Action string `json:"action"`
+	Via    Via    `json:"via"`
+	Done   bool   `json:"done"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Export is the data held about one customer.
+type Export struct {
+	Email     string                    `json:"email"`
+	CreatedAt time.Time                 `json:"created_at"`
+	Overview  *creemio.CustomerOverview `json:"-"`
+	Checklist []Item                    `json:"checklist"`
+}
+
+// Collect gathers the data held about the customer with email. The API
+// cannot look up checkouts and licenses by customer, pass the known ones in
+// opts. Unlike Overview, any failed part fails the export, as it has to be
+// complete.
+func Collect(ctx context.Context, c *creemio.Client, email string, opts *creemio.CustomerOverviewOptions) (*Export, error) {
+	if len(email) == 0 {
+		return nil, errNoEmail
+	}
+
+	overview, err := c.Customers.OverviewWithOptions(ctx, creemio.CustomerRequestQuery{Email: email}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("gdpr: %w", err)
+	}
+
+	return &Export{
+		Email:     email,
+		CreatedAt: time.Now().UTC(),
+		Overview:  overview,
+		Checklist: NewChecklist(overview),
+	}, nil
+}
+
+// NewChecklist lists what has to be erased for the customer of overview.
+// The API cannot delete customers, checkouts or licenses, so most steps
+// are carried out in the dashboard. Checkouts and licenses that were not
+// looked up get an item without an ID, as the export does not cover them.
+func NewChecklist(overview *creemio.CustomerOverview) []Item {
+	items := []Item{{
+		Object: "customer",
+		ID:     overview.Customer.ID,
+		Action: "Delete the customer profile (name, email, country)",
+		Via:    ViaDashboard,
+	}}
+
+	for _, sub := range overview.Subscriptions {
+		if sub.Status == creemio.SubscriptionStatusCanceled {
+			continue
+		}
+		items = append(items, Item{
+			Object: "subscription",
+			ID:     sub.ID,
+			Action: "Cancel the subscription",
+			Via:    ViaAPI,
+		})
+	}
+
+	if !overview.CheckoutsResolved {
+		items = append(items, Item{
+			Object: "checkout",
+			Action: "Find the checkouts of the customer, none were looked up",
+			Via:    ViaDashboard,
+		})
+	}
+	for _, checkout := range overview.Checkouts {
+		if len(checkout.Metadata) == 0 && len(checkout.CustomFields) == 0 {
+			continue
+		}
+		items = append(items, Item{
+			Object: "checkout",
+			ID:     checkout.ID,
+			Action: "Remove the metadata and custom field values",
+			Via:    ViaDashboard,
+		})
+	}
+
+	if !overview.LicensesResolved {
+		items = append(items, Item{
+			Object: "license",
+			Action: "Find the licenses of the customer, none were looked up",
+			Via:    ViaDashboard,
+		})
+	}
+	for _, license := range overview.Licenses {
+		items = append(items, Item{
+			Object: "license",
+			ID:     license.ID,
+			Action: "Disable the license key and remove its instances",
+			Via:    ViaDashboard,
+		})
+	}
+
+	for _, t := range overview.Transactions {
+		items = append(items, Item{
+			Object: "transaction",
+			ID:     t.ID,
+			Action: "Retain as a tax record, no longer use for other purposes",
+			Via:    ViaRetain,
+		})
+	}
+	return items
+}
+
+// Erase carries out the API items of the checklist and marks them done.
+// Failed items get an error and do not stop the others. Items are sent
+// through c, so dry-run mode and the production guard apply.
+func Erase(ctx context.Context, c *creemio.Client, items []Item) error {
+	var errs []error
+	for i := range items {
+		item := &items[i]
+		if item.Via != ViaAPI || item.Done {
+			continue
+		}
+
+		var err error
+		switch item.Object {
+		case "subscription":
+			_, _, err = c.Subscriptions.Cancel(ctx, item.ID)
+		default:
+			err = fmt.Errorf("unsupported object %q", item.Object)
+		}
+		if err != nil {
+			item.Error = err.Error()
+			errs = append(errs, fmt.Errorf("gdpr: %s %s: %w", item.Object, item.ID, err))
+			continue
+		}
+		item.Done = true
+		item.Error = ""
+	}
+	return errors.Join(errs...)
+}
+
+// WriteZip writes the export as a ZIP archive of JSON files, one per kind
+// of object, plus the checklist.
+func (e *Export) WriteZip(w io.Writer) error {
+	o := e.Overview
+	checkouts := make([]checkoutRecord, len(o.Checkouts))
+	for i, c := range o.Checkouts {
+		checkouts[i] = checkoutRecord{
+			ID:           c.ID,
+			Status:       c.Status,
+			Order:        c.Order,
+			Metadata:     c.Metadata,
+			CustomFields: c.CustomFields,
+		}
+		if c.Product != nil {
+			checkouts[i].ProductID = c.Product.ID
+		}
+	}
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"manifest.json", e},
+		{"customer.json", o.Customer},
+		{"transactions.json", orEmpty(o.Transactions)},
+		{"subscriptions.json", orEmpty(o.Subscriptions)},
+		{"checkouts.json", checkouts},
+		{"licenses.json", orEmpty(o.Licenses)},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     f.name,
+			Method:   zip.Deflate,
+			Modified: e.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// checkoutRecord is the personal data of a checkout, without the product
+// details that are the same for every buyer.
+type checkoutRecord struct {
+	ID           string                 `json:"id"`
+	Status       string                 `json:"status"`
+	ProductID    string                 `json:"product_id,omitempty"`
+	Order        *creemio.CheckoutOrder `json:"order,omitempty"`
+	Metadata     map[string]any         `json:"metadata,omitempty"`
+	CustomFields []creemio.CustomField  `json:"custom_fields,omitempty"`
+}
+
+// orEmpty encodes nil slices as empty JSON arrays.
+func orEmpty[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
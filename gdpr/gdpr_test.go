@@ -0,0 +1,141 @@
+package gdpr
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/evolvedevlab/creemio-go"
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T, canceled *atomic.Int32) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET /%s/customers", creemio.APIVersion), mock.HandleGetCustomer)
+	mux.HandleFunc(fmt.Sprintf("POST /%s/customers/billing", creemio.APIVersion), mock.HandleGetBillingPortalURL)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/transactions/search", creemio.APIVersion), mock.HandleGetTransactionList)
+	mux.HandleFunc(fmt.Sprintf("GET /%s/subscriptions", creemio.APIVersion), mock.HandleGetSubscription)
+	mux.HandleFunc(fmt.Sprintf("POST /%s/subscriptions/{id}/cancel", creemio.APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		canceled.Add(1)
+		mock.HandlePostCancelSubscription(w, r)
+	})
+	mux.HandleFunc(fmt.Sprintf("GET /%s/checkouts", creemio.APIVersion), func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("checkout_id") == "ch_missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "checkout not found"}`))
+			return
+		}
+		mock.HandleGetCheckout(w, r)
+	})
+	mux.HandleFunc(fmt.Sprintf("POST /%s/licenses/validate", creemio.APIVersion), mock.HandlePostValidateLicense)
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var canceled atomic.Int32
+	c := creemio.New(creemio.WithBaseURL(newServer(t, &canceled).URL))
+
+	export, err := Collect(context.Background(), c, "user@example.com", &creemio.CustomerOverviewOptions{
+		CheckoutIDs: []string{"ch_1"},
+		Licenses:    []creemio.LicenseValidateRequest{{Key: "ABC-123", InstanceID: "ins_1"}},
+	})
+	a.NoError(err)
+
+	vias := map[Via]int{}
+	for _, item := range export.Checklist {
+		vias[item.Via]++
+	}
+	a.Equal("customer", export.Checklist[0].Object)
+	a.Equal(map[Via]int{ViaDashboard: 3, ViaAPI: 2, ViaRetain: 2}, vias)
+
+	var buf bytes.Buffer
+	a.NoError(export.WriteZip(&buf))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	a.NoError(err)
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	a.Len(files, 6)
+
+	rc, err := files["checkouts.json"].Open()
+	a.NoError(err)
+	var checkouts []map[string]any
+	a.NoError(json.NewDecoder(rc).Decode(&checkouts))
+	rc.Close()
+	a.Len(checkouts, 1)
+	a.Contains(checkouts[0], "metadata")
+
+	rc, err = files["manifest.json"].Open()
+	a.NoError(err)
+	var manifest Export
+	a.NoError(json.NewDecoder(rc).Decode(&manifest))
+	rc.Close()
+	a.Equal("user@example.com", manifest.Email)
+	a.Equal(export.Checklist, manifest.Checklist)
+
+	// Only the subscriptions can be erased through the API.
+	a.NoError(Erase(context.Background(), c, export.Checklist))
+	a.EqualValues(2, canceled.Load())
+	for _, item := range export.Checklist {
+		a.Equal(item.Via == ViaAPI, item.Done)
+	}
+
+	// Done items are not repeated.
+	a.NoError(Erase(context.Background(), c, export.Checklist))
+	a.EqualValues(2, canceled.Load())
+}
+
+func TestCollect_Failure(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var canceled atomic.Int32
+	c := creemio.New(creemio.WithBaseURL(newServer(t, &canceled).URL))
+
+	// The checkout does not exist, so the export would be incomplete.
+	export, err := Collect(context.Background(), c, "user@example.com", &creemio.CustomerOverviewOptions{
+		CheckoutIDs: []string{"ch_1", "ch_missing"},
+	})
+	a.ErrorContains(err, "checkout ch_missing")
+	a.Nil(export)
+
+	// Without checkouts and licenses, the checklist says they are missing.
+	export, err = Collect(context.Background(), c, "user@example.com", nil)
+	a.NoError(err)
+	var missing []string
+	for _, item := range export.Checklist {
+		if len(item.ID) == 0 {
+			missing = append(missing, item.Object)
+		}
+	}
+	a.Equal([]string{"checkout", "license"}, missing)
+
+	_, err = Collect(context.Background(), c, "", nil)
+	a.ErrorIs(err, errNoEmail)
+}
+
+func TestErase_Unsupported(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	items := []Item{{Object: "license", ID: "lic_1", Via: ViaAPI}}
+	err := Erase(context.Background(), creemio.New(), items)
+	a.ErrorContains(err, "unsupported object")
+	a.False(items[0].Done)
+	a.NotEmpty(items[0].Error)
+}
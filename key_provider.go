@@ -0,0 +1,113 @@
+package creemio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyProvider supplies the API key and is consulted on every request, which
+// allows keys to be rotated without recreating the client.
+type KeyProvider interface {
+	APIKey(ctx context.Context) (string, error)
+	// Refresh is called when the API rejects the current key, before the
+	// request is retried once.
+	Refresh(ctx context.Context) error
+}
+
+// KeyProviderFunc adapts a callback to a KeyProvider. The callback is invoked
+// on every request, so Refresh is a no-op.
+type KeyProviderFunc func(ctx context.Context) (string, error)
+
+func (f KeyProviderFunc) APIKey(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+func (f KeyProviderFunc) Refresh(ctx context.Context) error {
+	return nil
+}
+
+// EnvKeyProvider reads the API key from an environment variable on every request.
+type EnvKeyProvider string
+
+func (p EnvKeyProvider) APIKey(ctx context.Context) (string, error) {
+	key, ok := os.LookupEnv(string(p))
+	if !ok || len(key) == 0 {
+		return "", fmt.Errorf("environment variable %s is not set", string(p))
+	}
+	return key, nil
+}
+
+func (p EnvKeyProvider) Refresh(ctx context.Context) error {
+	return nil
+}
+
+// FileKeyProvider reads the API key from a file, e.g. a mounted secret. The
+// file is polled at most once per interval and re-read when it changed.
+type FileKeyProvider struct {
+	path     string
+	interval time.Duration
+
+	mu        sync.Mutex
+	key       string
+	modTime   time.Time
+	size      int64
+	lastCheck time.Time
+}
+
+func NewFileKeyProvider(path string, interval time.Duration) *FileKeyProvider {
+	return &FileKeyProvider{
+		path:     path,
+		interval: interval,
+	}
+}
+
+func (p *FileKeyProvider) APIKey(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.key) > 0 && time.Since(p.lastCheck) < p.interval {
+		return p.key, nil
+	}
+	if err := p.load(false); err != nil {
+		return "", err
+	}
+	return p.key, nil
+}
+
+func (p *FileKeyProvider) Refresh(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.load(true)
+}
+
+// load re-reads the key if the file changed since the last read, or always if force is set.
+func (p *FileKeyProvider) load(force bool) error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return err
+	}
+	p.lastCheck = time.Now()
+
+	if !force && len(p.key) > 0 && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return err
+	}
+	key := strings.TrimSpace(string(data))
+	if len(key) == 0 {
+		return fmt.Errorf("key file %s is empty", p.path)
+	}
+
+	p.key = key
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	return nil
+}
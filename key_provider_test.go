@@ -0,0 +1,153 @@
+package creemio
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type rotatingKeyProvider struct {
+	keys      []string
+	current   atomic.Int32
+	refreshes atomic.Int32
+}
+
+func (p *rotatingKeyProvider) APIKey(ctx context.Context) (string, error) {
+	return p.keys[p.current.Load()], nil
+}
+
+func (p *rotatingKeyProvider) Refresh(ctx context.Context) error {
+	p.refreshes.Add(1)
+	p.current.Add(1)
+	return nil
+}
+
+func TestKeyProvider_RetriesOnUnauthorized(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var bodies []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("x-api-key") != "new-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status": 401, "error": "Unauthorized"}`))
+			return
+		}
+		mock.HandlePostValidateLicense(w, r)
+	}))
+	defer s.Close()
+
+	p := &rotatingKeyProvider{keys: []string{"old-key", "new-key"}}
+	c := New(
+		WithBaseURL(s.URL),
+		WithKeyProvider(p),
+	)
+
+	resp, res, err := c.Licenses.Validate(context.Background(), &LicenseValidateRequest{
+		Key:        "key-1",
+		InstanceID: "inst-1",
+	})
+
+	a.NoError(err)
+	a.NotNil(resp)
+	a.Equal(http.StatusOK, res.Status)
+	a.Equal(int32(1), p.refreshes.Load())
+	a.Len(bodies, 2)
+	a.Equal(bodies[0], bodies[1])
+}
+
+func TestKeyProvider_RetriesOnlyOnce(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var calls atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status": 401, "error": "Unauthorized"}`))
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithKeyProvider(KeyProviderFunc(func(ctx context.Context) (string, error) {
+			return "bad-key", nil
+		})),
+	)
+
+	resp, res, err := c.Products.Get(context.Background(), "prod_1")
+
+	a.Error(err)
+	a.Nil(resp)
+	a.Equal(http.StatusUnauthorized, res.Status)
+	a.Equal(int32(2), calls.Load())
+}
+
+func TestKeyProvider_DetectsEnvironment(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	c := New(WithKeyProvider(KeyProviderFunc(func(ctx context.Context) (string, error) {
+		return "creem_test_123", nil
+	})))
+
+	a.Equal(TestAPIURL, c.baseURL)
+	a.Equal(ModeTest, c.Mode())
+}
+
+func TestEnvKeyProvider(t *testing.T) {
+	a := assert.New(t)
+
+	p := EnvKeyProvider("CREEMIO_TEST_API_KEY")
+	_, err := p.APIKey(context.Background())
+	a.Error(err)
+
+	t.Setenv("CREEMIO_TEST_API_KEY", "creem_test_123")
+	key, err := p.APIKey(context.Background())
+	a.NoError(err)
+	a.Equal("creem_test_123", key)
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "api-key")
+	a.NoError(os.WriteFile(path, []byte("creem_test_old\n"), 0o600))
+
+	p := NewFileKeyProvider(path, time.Hour)
+	key, err := p.APIKey(context.Background())
+	a.NoError(err)
+	a.Equal("creem_test_old", key)
+
+	a.NoError(os.WriteFile(path, []byte("creem_test_rotated\n"), 0o600))
+
+	// Still within the polling interval.
+	key, err = p.APIKey(context.Background())
+	a.NoError(err)
+	a.Equal("creem_test_old", key)
+
+	a.NoError(p.Refresh(context.Background()))
+	key, err = p.APIKey(context.Background())
+	a.NoError(err)
+	a.Equal("creem_test_rotated", key)
+
+	p = NewFileKeyProvider(path, 0)
+	_, err = p.APIKey(context.Background())
+	a.NoError(err)
+	a.NoError(os.WriteFile(path, []byte("creem_test_polled"), 0o600))
+	key, err = p.APIKey(context.Background())
+	a.NoError(err)
+	a.Equal("creem_test_polled", key)
+}
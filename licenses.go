@@ -76,7 +76,13 @@ func (s *LicenseService) Activate(ctx context.Context, data *LicenseActivateRequ
 	req.Header.Set("x-api-key", s.client.apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service: "licenses",
+		action:  "activate",
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateActivate(data), nil
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -117,7 +123,14 @@ func (s *LicenseService) Deactivate(ctx context.Context, data *LicenseDeactivate
 	req.Header.Set("x-api-key", s.client.apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service: "licenses",
+		action:  "deactivate",
+		guarded: true,
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateDeactivate(ctx, data)
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -158,7 +171,7 @@ func (s *LicenseService) Validate(ctx context.Context, data *LicenseValidateRequ
 	req.Header.Set("x-api-key", s.client.apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doIdempotent(req)
 	if err != nil {
 		return nil, nil, err
 	}
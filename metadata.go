@@ -0,0 +1,330 @@
+package creemio
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits enforced client-side by EncodeMetadata.
+const (
+	MaxMetadataKeys        = 50
+	MaxMetadataKeyLength   = 40
+	MaxMetadataValueLength = 500
+)
+
+var (
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	timeType            = reflect.TypeFor[time.Time]()
+)
+
+// MetadataHolder is implemented by every model that carries metadata.
+type MetadataHolder interface {
+	metadata() map[string]any
+}
+
+func (c *Checkout) metadata() map[string]any                   { return c.Metadata }
+func (r *CheckoutCreateRequest) metadata() map[string]any      { return r.Metadata }
+func (s *Subscription) metadata() map[string]any               { return s.Metadata }
+func (w *WebHookCheckoutRequest) metadata() map[string]any     { return w.CheckoutObject.Metadata }
+func (w *WebHookSubscriptionRequest) metadata() map[string]any { return w.SubscriptionObject.Metadata }
+
+// EncodeMetadata encodes the exported fields of the struct v into a metadata map.
+//
+// Keys are taken from the `metadata` struct tag and default to the field name.
+// The tag supports the options "omitempty", and "string" which stores numbers
+// and booleans as strings so large IDs survive the JSON round trip intact:
+//
+//	type Meta struct {
+//		UserID int64  `metadata:"user_id,string"`
+//		OrgID  string `metadata:"org_id"`
+//		Ref    string `metadata:"ref,omitempty"`
+//		Secret string `metadata:"-"`
+//	}
+//
+// Supported field types are strings, booleans, integers, floats, time.Time,
+// types implementing encoding.TextMarshaler and pointers to any of these.
+func EncodeMetadata[T any](v T) (map[string]any, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("metadata: cannot encode nil %s", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("metadata: cannot encode %s, expected a struct", rv.Type())
+	}
+
+	m := make(map[string]any)
+	for _, f := range metadataFields(rv.Type()) {
+		if len(f.key) > MaxMetadataKeyLength {
+			return nil, fmt.Errorf("metadata: key %q exceeds %d characters", f.key, MaxMetadataKeyLength)
+		}
+
+		fv := rv.FieldByIndex(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+
+		value, err := encodeMetadataValue(fv, f.asString)
+		if err != nil {
+			return nil, fmt.Errorf("metadata: field %q: %w", f.key, err)
+		}
+		if s, ok := value.(string); ok && utf8.RuneCountInString(s) > MaxMetadataValueLength {
+			return nil, fmt.Errorf("metadata: field %q: value exceeds %d characters", f.key, MaxMetadataValueLength)
+		}
+		m[f.key] = value
+	}
+
+	if len(m) > MaxMetadataKeys {
+		return nil, fmt.Errorf("metadata: %d keys exceeds the limit of %d", len(m), MaxMetadataKeys)
+	}
+	return m, nil
+}
+
+// DecodeMetadata decodes a metadata map into a value of type T, which must be
+// a struct following the same tag rules as EncodeMetadata. Keys missing from
+// the map leave the field at its zero value, unknown keys are ignored.
+//
+// Numbers are accepted as JSON numbers or numeric strings, so IDs stored by
+// other clients decode regardless of how they were written.
+func DecodeMetadata[T any](m map[string]any) (T, error) {
+	var out T
+	rv := reflect.ValueOf(&out).Elem()
+	if rv.Kind() != reflect.Struct {
+		return out, fmt.Errorf("metadata: cannot decode into %s, expected a struct", rv.Type())
+	}
+
+	for _, f := range metadataFields(rv.Type()) {
+		raw, ok := m[f.key]
+		if !ok || raw == nil {
+			continue
+		}
+
+		fv := rv.FieldByIndex(f.index)
+		if fv.Kind() == reflect.Pointer {
+			fv.Set(reflect.New(fv.Type().Elem()))
+			fv = fv.Elem()
+		}
+		if err := decodeMetadataValue(fv, raw); err != nil {
+			return out, fmt.Errorf("metadata: field %q: %w", f.key, err)
+		}
+	}
+	return out, nil
+}
+
+// DecodeMetadataFrom decodes the metadata of a checkout, subscription or
+// webhook payload into a value of type T.
+func DecodeMetadataFrom[T any](h MetadataHolder) (T, error) {
+	return DecodeMetadata[T](h.metadata())
+}
+
+type metadataField struct {
+	key       string
+	index     []int
+	omitEmpty bool
+	asString  bool
+}
+
+func metadataFields(t reflect.Type) []metadataField {
+	fields := make([]metadataField, 0, t.NumField())
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+
+		tag := sf.Tag.Get("metadata")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if len(name) == 0 {
+			name = sf.Name
+		}
+
+		f := metadataField{key: name, index: sf.Index}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				f.omitEmpty = true
+			case "string":
+				f.asString = true
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func encodeMetadataValue(v reflect.Value, asString bool) (any, error) {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	}
+	if v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		if asString {
+			return strconv.FormatBool(v.Bool()), nil
+		}
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if asString {
+			return strconv.FormatInt(v.Int(), 10), nil
+		}
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if asString {
+			return strconv.FormatUint(v.Uint(), 10), nil
+		}
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		if asString {
+			return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+		}
+		return v.Float(), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", v.Type())
+}
+
+func decodeMetadataValue(v reflect.Value, raw any) error {
+	if v.Type() == timeType {
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("cannot decode %T into time.Time", raw)
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("cannot decode %T into %s", raw, v.Type())
+		}
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		switch r := raw.(type) {
+		case string:
+			v.SetString(r)
+		case json.Number:
+			v.SetString(r.String())
+		case float64:
+			v.SetString(strconv.FormatFloat(r, 'f', -1, 64))
+		case bool:
+			v.SetString(strconv.FormatBool(r))
+		default:
+			return fmt.Errorf("cannot decode %T into %s", raw, v.Type())
+		}
+	case reflect.Bool:
+		switch r := raw.(type) {
+		case bool:
+			v.SetBool(r)
+		case string:
+			b, err := strconv.ParseBool(r)
+			if err != nil {
+				return err
+			}
+			v.SetBool(b)
+		default:
+			return fmt.Errorf("cannot decode %T into %s", raw, v.Type())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := metadataNumber(raw, func(s string) (any, error) { return strconv.ParseInt(s, 10, 64) })
+		if err != nil {
+			return err
+		}
+		var i int64
+		switch n := n.(type) {
+		case int64:
+			i = n
+		case float64:
+			if n != math.Trunc(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+				return fmt.Errorf("%v is not a valid integer", n)
+			}
+			i = int64(n)
+		}
+		if v.OverflowInt(i) {
+			return fmt.Errorf("%d overflows %s", i, v.Type())
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := metadataNumber(raw, func(s string) (any, error) { return strconv.ParseUint(s, 10, 64) })
+		if err != nil {
+			return err
+		}
+		var u uint64
+		switch n := n.(type) {
+		case uint64:
+			u = n
+		case float64:
+			if n < 0 || n != math.Trunc(n) || n >= math.MaxUint64 {
+				return fmt.Errorf("%v is not a valid unsigned integer", n)
+			}
+			u = uint64(n)
+		}
+		if v.OverflowUint(u) {
+			return fmt.Errorf("%d overflows %s", u, v.Type())
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		n, err := metadataNumber(raw, func(s string) (any, error) { return strconv.ParseFloat(s, 64) })
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n.(float64))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// metadataNumber normalizes raw into either a float64 or the result of parse,
+// which is used for strings and json.Number to avoid float precision loss.
+func metadataNumber(raw any, parse func(string) (any, error)) (any, error) {
+	switch r := raw.(type) {
+	case string:
+		return parse(r)
+	case json.Number:
+		return parse(r.String())
+	case float64:
+		return r, nil
+	case float32:
+		return float64(r), nil
+	case int:
+		return parse(strconv.Itoa(r))
+	case int64:
+		return parse(strconv.FormatInt(r, 10))
+	case uint64:
+		return parse(strconv.FormatUint(r, 10))
+	}
+	return nil, fmt.Errorf("cannot decode %T as a number", raw)
+}
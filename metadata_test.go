@@ -0,0 +1,107 @@
+package creemio
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type testMetadata struct {
+	UserID    int64      `metadata:"user_id,string"`
+	OrgID     uint32     `metadata:"org_id"`
+	Plan      string     `metadata:"plan"`
+	Trial     bool       `metadata:"trial"`
+	Ref       string     `metadata:"ref,omitempty"`
+	InvitedAt *time.Time `metadata:"invited_at"`
+	Internal  string     `metadata:"-"`
+	Seats     int
+}
+
+func TestMetadata_RoundTrip(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	invitedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	in := testMetadata{
+		UserID:    9007199254740993, // not representable as float64
+		OrgID:     42,
+		Plan:      "pro",
+		Trial:     true,
+		InvitedAt: &invitedAt,
+		Internal:  "secret",
+		Seats:     3,
+	}
+
+	m, err := EncodeMetadata(in)
+	a.NoError(err)
+	a.Equal(map[string]any{
+		"user_id":    "9007199254740993",
+		"org_id":     uint64(42),
+		"plan":       "pro",
+		"trial":      true,
+		"invited_at": "2025-01-02T03:04:05Z",
+		"Seats":      int64(3),
+	}, m)
+
+	// Simulate the round trip through the API.
+	data, err := json.Marshal(&CheckoutCreateRequest{ProductID: "prod_1", Metadata: m})
+	a.NoError(err)
+	var checkout Checkout
+	a.NoError(json.Unmarshal(data, &checkout))
+
+	out, err := DecodeMetadataFrom[testMetadata](&checkout)
+	a.NoError(err)
+
+	in.Internal = ""
+	a.Equal(in, out)
+}
+
+func TestMetadata_DecodeFromWebHook(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var event WebHookCheckoutRequest
+	a.NoError(json.Unmarshal(mock.GetCheckoutResponse(), &event.CheckoutObject))
+
+	type meta struct {
+		UserID     string `metadata:"userId"`
+		VisitCount int    `metadata:"visitCount"`
+		LastVisit  string `metadata:"lastVisit"`
+	}
+
+	out, err := DecodeMetadataFrom[meta](&event)
+	a.NoError(err)
+	a.Equal(meta{UserID: "user_123", VisitCount: 42, LastVisit: "2023-04-01"}, out)
+}
+
+func TestMetadata_Errors(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	_, err := EncodeMetadata("not a struct")
+	a.Error(err)
+
+	_, err = EncodeMetadata(struct {
+		Tags []string
+	}{})
+	a.Error(err)
+
+	_, err = EncodeMetadata(struct {
+		Note string `metadata:"note"`
+	}{Note: strings.Repeat("a", MaxMetadataValueLength+1)})
+	a.Error(err)
+
+	_, err = DecodeMetadata[struct {
+		Count int8 `metadata:"count"`
+	}](map[string]any{"count": float64(1000)})
+	a.Error(err)
+
+	_, err = DecodeMetadata[struct {
+		Count int `metadata:"count"`
+	}](map[string]any{"count": 1.5})
+	a.Error(err)
+}
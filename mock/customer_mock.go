@@ -12,6 +12,16 @@ func HandleGetCustomerList(w http.ResponseWriter, r *http.Request) {
 	w.Write(GetCustomerListResponse())
 }
 
+func HandlePostCreateCustomer(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write(GetCustomerResponse())
+}
+
+func HandlePostUpdateCustomer(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write(GetCustomerResponse())
+}
+
 func HandleGetBillingPortalURL(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(`{"customer_portal_link": "https://creem.io/my-orders/login/xxxxxxxxxx"}`))
@@ -22,6 +22,62 @@ func HandleDeleteDiscount(w http.ResponseWriter, r *http.Request) {
 	w.Write(GetDiscountResponse())
 }
 
+func HandlePostUpdateDiscount(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write(GetDiscountResponse())
+}
+
+func HandleGetDiscountList(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write(GetDiscountListResponse())
+}
+
+func GetDiscountListResponse() []byte {
+	return []byte(`{
+  "items": [
+    {
+      "id": "dis_6SIeFZHfREPdOsopCVegdD",
+      "object": "discount",
+      "status": "active",
+      "name": "KING100",
+      "code": "KING100",
+      "type": "percentage",
+      "percentage": 100,
+      "duration": "once",
+      "max_redemptions": 10,
+      "applies_to_products": [
+        "prod_10rxEcEUn5fYkSBpXWhdjR"
+      ],
+      "mode": "test"
+    },
+    {
+      "id": "dis_1aBcDeFgHiJkLmNoPqRsTu",
+      "object": "discount",
+      "status": "active",
+      "name": "SAVE5",
+      "code": "SAVE5",
+      "type": "fixed",
+      "amount": 500,
+      "currency": "EUR",
+      "duration": "repeating",
+      "duration_in_months": 3,
+      "expiry_date": "2026-01-01T00:00:00Z",
+      "applies_to_products": [
+        "prod_10rxEcEUn5fYkSBpXWhdjR"
+      ],
+      "mode": "test"
+    }
+  ],
+  "pagination": {
+    "total_records": 2,
+    "total_pages": 1,
+    "current_page": 1,
+    "next_page": 0,
+    "prev_page": null
+  }
+}`)
+}
+
 func GetDiscountResponse() []byte {
 	return []byte(`{  
 	"id": "dis_6SIeFZHfREPdOsopCVegdD",
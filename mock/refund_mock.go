@@ -0,0 +1,85 @@
+package mock
+
+import "net/http"
+
+func HandlePostCreateRefund(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write(GetRefundResponse())
+}
+
+func HandleGetRefund(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write(GetRefundResponse())
+}
+
+func HandleGetRefundList(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write(GetRefundListResponse())
+}
+
+func GetRefundResponse() []byte {
+	return []byte(`{
+  "id": "ref_4bTSmUA5TDHcuXQFYdXr5A",
+  "object": "refund",
+  "status": "succeeded",
+  "refund_amount": 1500,
+  "refund_currency": "USD",
+  "reason": "requested_by_customer",
+  "transaction": {
+    "id": "txn_1234567890",
+    "object": "transaction",
+    "amount": 2000,
+    "amount_paid": 2000,
+    "currency": "USD",
+    "type": "payment",
+    "status": "partialRefund",
+    "refunded_amount": 1500,
+    "customer": "cust_4tyb89MPnfXrumvx0kwXdG",
+    "mode": "test"
+  },
+  "customer": {
+    "id": "cust_4tyb89MPnfXrumvx0kwXdG",
+    "object": "customer",
+    "email": "user@example.com",
+    "name": "John Doe",
+    "country": "US",
+    "mode": "test"
+  },
+  "created_at": 1728734327355,
+  "mode": "test"
+}`)
+}
+
+func GetRefundListResponse() []byte {
+	return []byte(`{
+  "items": [
+    {
+      "id": "ref_4bTSmUA5TDHcuXQFYdXr5A",
+      "object": "refund",
+      "status": "succeeded",
+      "refund_amount": 1500,
+      "refund_currency": "USD",
+      "reason": "requested_by_customer",
+      "created_at": 1728734327355,
+      "mode": "test"
+    },
+    {
+      "id": "ref_7YwQxvR2nD1kPzLmH3sT9a",
+      "object": "refund",
+      "status": "pending",
+      "refund_amount": 500,
+      "refund_currency": "USD",
+      "reason": "duplicate",
+      "created_at": 1728820727355,
+      "mode": "test"
+    }
+  ],
+  "pagination": {
+    "total_records": 2,
+    "total_pages": 1,
+    "current_page": 1,
+    "next_page": 0,
+    "prev_page": null
+  }
+}`)
+}
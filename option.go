@@ -1,6 +1,9 @@
 package creemio
 
-import "net/http"
+import (
+	"log/slog"
+	"net/http"
+)
 
 func WithBaseURL(url string) Option {
 	return func(c *Client) {
@@ -19,3 +22,101 @@ func WithAPIKey(key string) Option {
 		c.apiKey = key
 	}
 }
+
+// WithKeyProvider sets a KeyProvider consulted on every request, it takes
+// precedence over WithAPIKey.
+func WithKeyProvider(provider KeyProvider) Option {
+	return func(c *Client) {
+		c.keyProvider = provider
+	}
+}
+
+// WithMode sets the environment the client is expected to talk to. By default
+// it is detected from the base URL and the API key prefix.
+func WithMode(mode Mode) Option {
+	return func(c *Client) {
+		c.mode = mode
+	}
+}
+
+// WithStrictMode makes every call fail with a *ModeMismatchError if the API
+// responds with objects from a different environment than the client expects.
+func WithStrictMode() Option {
+	return func(c *Client) {
+		c.strictMode = true
+	}
+}
+
+// WithProductionGuard refuses calls that change existing billing state, such
+// as cancelling, upgrading or deleting, while the client is in production mode.
+// Use AllowProductionMutations on the context to let a call through.
+func WithProductionGuard() Option {
+	return func(c *Client) {
+		c.productionGuard = true
+	}
+}
+
+// WithDryRun stops mutating calls from being sent. Instead the method, URL and
+// payload are logged to logger and a response is synthesized from the current
+// object, fetched with the corresponding Get call. Synthesized responses carry
+// the DryRunHeader. A nil logger uses slog.Default.
+func WithDryRun(logger *slog.Logger) Option {
+	return func(c *Client) {
+		if logger == nil {
+			logger = slog.Default()
+		}
+		c.dryRun = true
+		c.dryRunLogger = logger
+	}
+}
+
+// WithAuditSink records every mutating call, including refused and dry-run
+// calls, to sink. Payload fields listed in DefaultAuditRedactedFields are
+// redacted unless changed with WithAuditRedactedFields.
+func WithAuditSink(sink AuditSink) Option {
+	return func(c *Client) {
+		c.auditSink = sink
+	}
+}
+
+// WithAuditRedactedFields replaces the payload fields redacted in audit entries.
+func WithAuditRedactedFields(fields ...string) Option {
+	return func(c *Client) {
+		c.auditRedactedFields = fields
+	}
+}
+
+// WithAuditErrorHandler is called when the audit sink fails to record an
+// entry. The call itself is not affected, by default the error is logged
+// with slog.Default.
+func WithAuditErrorHandler(handler func(entry *AuditEntry, err error)) Option {
+	return func(c *Client) {
+		c.auditErrorHandler = handler
+	}
+}
+
+// WithCache caches the responses of product, discount and customer reads in
+// backend for the durations in ttl, see DefaultCacheTTL. A nil backend uses
+// an in-memory LRU cache of DefaultCacheSize entries. Mutating calls evict
+// every cached response of the resource they change, and WithoutCache skips
+// the cache for a single call.
+func WithCache(backend Cache, ttl CacheTTL) Option {
+	return func(c *Client) {
+		if backend == nil {
+			backend = NewLRUCache(DefaultCacheSize)
+		}
+		c.cache = &responseCache{
+			backend:     backend,
+			ttl:         ttl,
+			generations: make(map[string]uint64),
+		}
+	}
+}
+
+// WithoutRequestCoalescing sends every read on its own instead of sharing one
+// round trip between concurrent identical calls.
+func WithoutRequestCoalescing() Option {
+	return func(c *Client) {
+		c.flights = nil
+	}
+}
@@ -0,0 +1,261 @@
+package creemio
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+// TenantConfig holds the credentials of a single creemio store.
+type TenantConfig struct {
+	APIKey        string
+	WebhookSecret string
+	// BaseURL and Mode are optional, by default they are inferred from APIKey.
+	BaseURL string
+	Mode    Mode
+	// Options are applied after the pool wide options.
+	Options []Option
+}
+
+// TenantLoader loads the configuration of a tenant that is not registered yet.
+// A nil config without an error means the tenant does not exist.
+type TenantLoader func(ctx context.Context, tenantID string) (*TenantConfig, error)
+
+type PoolOption func(*ClientPool)
+
+// WithPoolHTTPClient replaces the HTTP client shared by all tenants.
+func WithPoolHTTPClient(client *http.Client) PoolOption {
+	return func(p *ClientPool) {
+		p.httpClient = client
+	}
+}
+
+// WithPoolOptions sets client options applied to every tenant's client.
+func WithPoolOptions(opts ...Option) PoolOption {
+	return func(p *ClientPool) {
+		p.opts = append(p.opts, opts...)
+	}
+}
+
+// WithTenantLoader sets a loader used to lazily fetch unknown tenants, for
+// example from a database.
+func WithTenantLoader(loader TenantLoader) PoolOption {
+	return func(p *ClientPool) {
+		p.loader = loader
+	}
+}
+
+type tenant struct {
+	config   TenantConfig
+	client   *Client
+	lastUsed time.Time
+}
+
+// ClientPool manages one Client per tenant for platforms serving many
+// creemio stores. All clients share one HTTP transport and are created lazily
+// on first use. It is safe for concurrent use.
+type ClientPool struct {
+	httpClient *http.Client
+	opts       []Option
+	loader     TenantLoader
+
+	mu      sync.Mutex
+	tenants map[string]*tenant
+}
+
+func NewClientPool(opts ...PoolOption) *ClientPool {
+	p := &ClientPool{
+		httpClient: &http.Client{Transport: newPoolTransport()},
+		tenants:    make(map[string]*tenant),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// newPoolTransport returns a transport tuned to keep connections to the API
+// alive across many tenants.
+func newPoolTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          200,
+		MaxIdleConnsPerHost:   100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// Register adds or replaces a tenant. A previously created client for the
+// tenant is dropped.
+func (p *ClientPool) Register(tenantID string, config TenantConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.tenants[tenantID] = &tenant{config: config}
+}
+
+// Remove forgets a tenant and its client.
+func (p *ClientPool) Remove(tenantID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.tenants, tenantID)
+}
+
+// Client returns the client of a tenant, creating it on first use. Unknown
+// tenants are loaded with the TenantLoader if one is configured, otherwise
+// ErrUnknownTenant is returned.
+func (p *ClientPool) Client(ctx context.Context, tenantID string) (*Client, error) {
+	t, err := p.tenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if t.client == nil {
+		t.client = p.newClient(t.config)
+	}
+	t.lastUsed = time.Now()
+	return t.client, nil
+}
+
+func (p *ClientPool) tenant(ctx context.Context, tenantID string) (*tenant, error) {
+	p.mu.Lock()
+	t, ok := p.tenants[tenantID]
+	p.mu.Unlock()
+	if ok {
+		return t, nil
+	}
+
+	if p.loader == nil {
+		return nil, ErrUnknownTenant
+	}
+	config, err := p.loader(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, ErrUnknownTenant
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Another caller may have loaded the tenant in the meantime.
+	if t, ok := p.tenants[tenantID]; ok {
+		return t, nil
+	}
+	t = &tenant{config: *config}
+	p.tenants[tenantID] = t
+	return t, nil
+}
+
+func (p *ClientPool) newClient(config TenantConfig) *Client {
+	opts := make([]Option, 0, len(p.opts)+len(config.Options)+4)
+	opts = append(opts, WithHTTPClient(p.httpClient))
+	opts = append(opts, p.opts...)
+	opts = append(opts, WithAPIKey(config.APIKey))
+	if len(config.BaseURL) > 0 {
+		opts = append(opts, WithBaseURL(config.BaseURL))
+	}
+	if len(config.Mode) > 0 {
+		opts = append(opts, WithMode(config.Mode))
+	}
+	opts = append(opts, config.Options...)
+
+	return New(opts...)
+}
+
+// RotateAPIKey replaces the API key of a tenant. Clients already handed out
+// keep the old key, subsequent calls to Client return a client using the new one.
+func (p *ClientPool) RotateAPIKey(tenantID, apiKey string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t, ok := p.tenants[tenantID]
+	if !ok {
+		return ErrUnknownTenant
+	}
+	t.config.APIKey = apiKey
+	t.client = nil
+	return nil
+}
+
+// RotateWebhookSecret replaces the webhook secret of a tenant.
+func (p *ClientPool) RotateWebhookSecret(tenantID, secret string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t, ok := p.tenants[tenantID]
+	if !ok {
+		return ErrUnknownTenant
+	}
+	t.config.WebhookSecret = secret
+	return nil
+}
+
+// Evict drops the client of a tenant while keeping its configuration, the
+// client is recreated on next use.
+func (p *ClientPool) Evict(tenantID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if t, ok := p.tenants[tenantID]; ok {
+		t.client = nil
+	}
+}
+
+// EvictIdle drops every client not used within maxIdle and returns how many
+// were evicted.
+func (p *ClientPool) EvictIdle(maxIdle time.Duration) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var n int
+	for _, t := range p.tenants {
+		if t.client != nil && time.Since(t.lastUsed) > maxIdle {
+			t.client = nil
+			n++
+		}
+	}
+	return n
+}
+
+// WebhookSecret returns the webhook secret of a tenant.
+func (p *ClientPool) WebhookSecret(ctx context.Context, tenantID string) (string, error) {
+	t, err := p.tenant(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return t.config.WebhookSecret, nil
+}
+
+// VerifyWebhook checks the signature of a webhook body against the secret of
+// the given tenant.
+func (p *ClientPool) VerifyWebhook(ctx context.Context, tenantID string, body []byte, signature string) (bool, error) {
+	secret, err := p.WebhookSecret(ctx, tenantID)
+	if err != nil {
+		return false, err
+	}
+	return VerifyWebHookSignature(body, signature, secret), nil
+}
@@ -0,0 +1,130 @@
+package creemio
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientPool_Client(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("x-api-key"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "prod_1"}`))
+	}))
+	defer s.Close()
+
+	p := NewClientPool(WithPoolOptions(WithBaseURL(s.URL)))
+	p.Register("acme", TenantConfig{APIKey: "creem_test_acme"})
+	p.Register("globex", TenantConfig{APIKey: "creem_globex", Mode: ModeTest})
+
+	acme, err := p.Client(context.Background(), "acme")
+	a.NoError(err)
+	globex, err := p.Client(context.Background(), "globex")
+	a.NoError(err)
+
+	again, err := p.Client(context.Background(), "acme")
+	a.NoError(err)
+	a.Same(acme, again)
+	a.Same(acme.httpClient, globex.httpClient)
+	a.Equal(ModeTest, acme.Mode())
+	a.Equal(ModeTest, globex.Mode())
+
+	_, _, err = acme.Products.Get(context.Background(), "prod_1")
+	a.NoError(err)
+	_, _, err = globex.Products.Get(context.Background(), "prod_1")
+	a.NoError(err)
+	a.Equal([]string{"creem_test_acme", "creem_globex"}, keys)
+
+	_, err = p.Client(context.Background(), "initech")
+	a.ErrorIs(err, ErrUnknownTenant)
+}
+
+func TestClientPool_RotateAndEvict(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	p := NewClientPool()
+	p.Register("acme", TenantConfig{APIKey: "creem_test_old"})
+
+	before, err := p.Client(context.Background(), "acme")
+	a.NoError(err)
+
+	a.NoError(p.RotateAPIKey("acme", "creem_test_new"))
+	after, err := p.Client(context.Background(), "acme")
+	a.NoError(err)
+	a.NotSame(before, after)
+	a.Equal("creem_test_old", before.apiKey)
+	a.Equal("creem_test_new", after.apiKey)
+
+	a.Equal(0, p.EvictIdle(time.Hour))
+	a.Equal(1, p.EvictIdle(0))
+
+	evicted, err := p.Client(context.Background(), "acme")
+	a.NoError(err)
+	a.NotSame(after, evicted)
+
+	p.Remove("acme")
+	_, err = p.Client(context.Background(), "acme")
+	a.ErrorIs(err, ErrUnknownTenant)
+	a.ErrorIs(p.RotateAPIKey("acme", "creem_test_new"), ErrUnknownTenant)
+}
+
+func TestClientPool_Loader(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	errNotFound := errors.New("not found")
+	p := NewClientPool(WithTenantLoader(func(ctx context.Context, tenantID string) (*TenantConfig, error) {
+		switch tenantID {
+		case "acme":
+			return &TenantConfig{APIKey: "creem_acme", WebhookSecret: "whsec"}, nil
+		case "initech":
+			// The loader does not know the tenant.
+			return nil, nil
+		}
+		return nil, errNotFound
+	}))
+
+	c, err := p.Client(context.Background(), "acme")
+	a.NoError(err)
+	a.Equal(APIURL, c.baseURL)
+
+	_, err = p.Client(context.Background(), "globex")
+	a.ErrorIs(err, errNotFound)
+
+	_, err = p.Client(context.Background(), "initech")
+	a.ErrorIs(err, ErrUnknownTenant)
+
+	body := []byte(`{"id": "evt_1"}`)
+	mac := hmac.New(sha256.New, []byte("whsec"))
+	mac.Write(body)
+	sig := hex.EncodeToString(mac.Sum(nil))
+
+	ok, err := p.VerifyWebhook(context.Background(), "acme", body, sig)
+	a.NoError(err)
+	a.True(ok)
+
+	a.NoError(p.RotateWebhookSecret("acme", "rotated"))
+	ok, err = p.VerifyWebhook(context.Background(), "acme", body, sig)
+	a.NoError(err)
+	a.False(ok)
+}
@@ -116,7 +116,13 @@ func (s *ProductService) Create(ctx context.Context, data *CreateProductRequest)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-api-key", s.client.apiKey)
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service: "products",
+		action:  "create",
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateCreate(data), nil
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -152,7 +158,7 @@ func (s *ProductService) Get(ctx context.Context, id string) (*Product, *Respons
 	q.Set("product_id", id)
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -174,6 +180,12 @@ func (s *ProductService) Get(ctx context.Context, id string) (*Product, *Respons
 	return &product, newResponse(res, body), nil
 }
 
+// GetMany fetches the products with the given IDs concurrently, see BatchOptions.
+// Results are in the order of ids, each with its own error.
+func (s *ProductService) GetMany(ctx context.Context, ids []string, opts *BatchOptions) []BatchResult[Product] {
+	return getMany(ctx, ids, opts, s.Get)
+}
+
 func (s *ProductService) List(ctx context.Context, query *ProductListQuery) (*ProductList, *Response, error) {
 	targetUrl := makeUrl(s.client.baseURL, "/products", "search")
 
@@ -195,7 +207,7 @@ func (s *ProductService) List(ctx context.Context, query *ProductListQuery) (*Pr
 		req.URL.RawQuery = q.Encode()
 	}
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,213 @@
+package creemio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var errRefundAmountNegative = errors.New("amount must not be negative")
+
+const (
+	RefundReasonDuplicate           = "duplicate"
+	RefundReasonFraudulent          = "fraudulent"
+	RefundReasonRequestedByCustomer = "requested_by_customer"
+	RefundReasonOther               = "other"
+)
+
+type CreateRefundRequest struct {
+	TransactionID string `json:"transaction_id"`
+	// Amount in the smallest currency unit, zero refunds the remaining
+	// amount of the transaction.
+	Amount int    `json:"amount,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type RefundList struct {
+	Items      []Refund   `json:"items"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type RefundListQuery struct {
+	CustomerID    string
+	TransactionID string
+	// CreatedAfter and CreatedBefore bound the creation time, zero values
+	// are ignored.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	PageNumber    int
+	PageSize      int
+}
+
+type RefundService struct {
+	client *Client
+}
+
+func (s *RefundService) Create(ctx context.Context, data *CreateRefundRequest) (*Refund, *Response, error) {
+	targetUrl := makeUrl(s.client.baseURL, "/refunds")
+
+	if data == nil {
+		return nil, nil, errRequiredMissingField
+	}
+	if len(data.TransactionID) == 0 {
+		return nil, nil, errRequiredFieldTransactionID
+	}
+	if data.Amount < 0 {
+		return nil, nil, errRefundAmountNegative
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetUrl, bytes.NewReader(payload))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", s.client.apiKey)
+
+	res, err := s.client.doMutation(req, mutation{
+		service: "refunds",
+		action:  "create",
+		// Refunds move money on an existing transaction.
+		guarded: true,
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateCreate(ctx, data)
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, newResponse(res, body), err
+	}
+	if res.StatusCode >= 400 {
+		return nil, newResponse(res, body), newAPIError(body)
+	}
+
+	var refund Refund
+	if err := json.Unmarshal(body, &refund); err != nil {
+		return nil, newResponse(res, body), err
+	}
+
+	return &refund, newResponse(res, body), nil
+}
+
+func (s *RefundService) Get(ctx context.Context, id string) (*Refund, *Response, error) {
+	targetUrl := makeUrl(s.client.baseURL, "/refunds")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetUrl, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("x-api-key", s.client.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	q := req.URL.Query()
+	q.Set("refund_id", id)
+	req.URL.RawQuery = q.Encode()
+
+	res, err := s.client.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, newResponse(res, body), err
+	}
+	if res.StatusCode >= 400 {
+		return nil, newResponse(res, body), newAPIError(body)
+	}
+
+	var refund Refund
+	if err := json.Unmarshal(body, &refund); err != nil {
+		return nil, newResponse(res, body), err
+	}
+
+	return &refund, newResponse(res, body), nil
+}
+
+func (s *RefundService) List(ctx context.Context, query *RefundListQuery) (*RefundList, *Response, error) {
+	targetUrl := makeUrl(s.client.baseURL, "/refunds", "search")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetUrl, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("x-api-key", s.client.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	if query != nil {
+		q := req.URL.Query()
+		if len(query.CustomerID) > 0 {
+			q.Set("customer_id", query.CustomerID)
+		}
+		if len(query.TransactionID) > 0 {
+			q.Set("transaction_id", query.TransactionID)
+		}
+		if !query.CreatedAfter.IsZero() {
+			q.Set("created_after", strconv.FormatInt(query.CreatedAfter.UnixMilli(), 10))
+		}
+		if !query.CreatedBefore.IsZero() {
+			q.Set("created_before", strconv.FormatInt(query.CreatedBefore.UnixMilli(), 10))
+		}
+		if query.PageNumber > 0 {
+			q.Set("page_number", strconv.Itoa(query.PageNumber))
+		}
+		if query.PageSize > 0 {
+			q.Set("page_size", strconv.Itoa(query.PageSize))
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	res, err := s.client.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, newResponse(res, body), err
+	}
+	if res.StatusCode >= 400 {
+		return nil, newResponse(res, body), newAPIError(body)
+	}
+
+	var result RefundList
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, newResponse(res, body), err
+	}
+
+	return &result, newResponse(res, body), nil
+}
+
+// All iterates over the refunds matching query on every page, starting at
+// query.PageNumber. Iteration stops at the first error.
+func (s *RefundService) All(ctx context.Context, query *RefundListQuery) iter.Seq2[Refund, error] {
+	q := RefundListQuery{}
+	if query != nil {
+		q = *query
+	}
+	return paginate(q.PageNumber, func(page int) ([]Refund, Pagination, error) {
+		q.PageNumber = page
+		list, _, err := s.List(ctx, &q)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+}
@@ -0,0 +1,268 @@
+package creemio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/evolvedevlab/creemio-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefunds_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var payload map[string]any
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+		mock.HandlePostCreateRefund(w, r)
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	// For comparing the url request url with search params
+	url, err := url.Parse(fmt.Sprintf("/%s/refunds", APIVersion))
+	if err != nil {
+		panic(err)
+	}
+
+	resp, res, err := c.Refunds.Create(context.Background(), &CreateRefundRequest{
+		TransactionID: "txn_1234567890",
+		Amount:        1500,
+		Reason:        RefundReasonRequestedByCustomer,
+	})
+
+	a.NoError(err)
+	a.Equal(url.RequestURI(), res.RequestURL.RequestURI())
+	a.Equal(http.StatusOK, res.Status)
+	a.NotNil(resp)
+	a.Equal(map[string]any{
+		"transaction_id": "txn_1234567890",
+		"amount":         float64(1500),
+		"reason":         "requested_by_customer",
+	}, payload)
+
+	var expected Refund
+	err = json.Unmarshal(mock.GetRefundResponse(), &expected)
+
+	a.NoError(err)
+	a.Equal(expected, *resp)
+	a.Equal("txn_1234567890", resp.Transaction.ID)
+}
+
+func TestRefunds_CreateWithMissingRequiredField(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandlePostCreateRefund))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	resp, res, err := c.Refunds.Create(context.Background(), nil)
+	a.Nil(resp)
+	a.Nil(res)
+	a.EqualError(err, errRequiredMissingField.Error())
+
+	_, _, err = c.Refunds.Create(context.Background(), &CreateRefundRequest{Amount: 100})
+	a.EqualError(err, errRequiredFieldTransactionID.Error())
+
+	_, _, err = c.Refunds.Create(context.Background(), &CreateRefundRequest{TransactionID: "txn_1", Amount: -1})
+	a.EqualError(err, errRefundAmountNegative.Error())
+}
+
+func TestRefunds_CreateWithError(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	resp, res, err := c.Refunds.Create(context.Background(), &CreateRefundRequest{
+		TransactionID: "txn_1234567890",
+	})
+
+	a.Error(err)
+	a.Nil(resp)
+	a.NotNil(res)
+	a.Equal(http.StatusInternalServerError, res.Status)
+}
+
+func TestRefunds_CreateDryRun(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET /%s/transactions", APIVersion), mock.HandleGetTransaction)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithDryRun(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	)
+
+	resp, _, err := c.Refunds.Create(context.Background(), &CreateRefundRequest{
+		TransactionID: "txn_1234567890",
+		Amount:        700,
+	})
+
+	a.NoError(err)
+	a.Equal(DryRunID, resp.ID)
+	a.Equal(700, resp.RefundAmount)
+	a.Equal("USD", resp.RefundCurrency)
+	a.Equal("txn_1234567890", resp.Transaction.ID)
+}
+
+func TestRefunds_Get(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandleGetRefund))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	refundID := "ref_4bTSmUA5TDHcuXQFYdXr5A"
+	// For comparing the url request url with search params
+	url, err := url.Parse(fmt.Sprintf("/%s/refunds", APIVersion))
+	if err != nil {
+		panic(err)
+	}
+	q := url.Query()
+	q.Set("refund_id", refundID)
+	url.RawQuery = q.Encode()
+
+	resp, res, err := c.Refunds.Get(context.Background(), refundID)
+
+	a.NoError(err)
+	a.Equal(url.RequestURI(), res.RequestURL.RequestURI())
+	a.Equal(http.StatusOK, res.Status)
+	a.NotNil(resp)
+
+	var expected Refund
+	err = json.Unmarshal(mock.GetRefundResponse(), &expected)
+
+	a.NoError(err)
+	a.Equal(expected, *resp)
+}
+
+func TestRefunds_GetWithError(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	resp, res, err := c.Refunds.Get(context.Background(), "ref_1")
+
+	a.Error(err)
+	a.Nil(resp)
+	a.NotNil(res)
+	a.Equal(http.StatusInternalServerError, res.Status)
+}
+
+func TestRefunds_List(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(mock.HandleGetRefundList))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	after := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	// For comparing the url request url with search params
+	url, err := url.Parse(fmt.Sprintf("/%s/refunds/search", APIVersion))
+	if err != nil {
+		panic(err)
+	}
+	q := url.Query()
+	q.Set("customer_id", "cust_1")
+	q.Set("transaction_id", "txn_1")
+	q.Set("created_after", "1727740800000")
+	q.Set("created_before", "1730419200000")
+	q.Set("page_number", "1")
+	q.Set("page_size", "20")
+	url.RawQuery = q.Encode()
+
+	resp, res, err := c.Refunds.List(context.Background(), &RefundListQuery{
+		CustomerID:    "cust_1",
+		TransactionID: "txn_1",
+		CreatedAfter:  after,
+		CreatedBefore: before,
+		PageNumber:    1,
+		PageSize:      20,
+	})
+
+	a.NoError(err)
+	a.Equal(url.RequestURI(), res.RequestURL.RequestURI())
+	a.Equal(http.StatusOK, res.Status)
+	a.NotNil(resp)
+
+	var expected RefundList
+	err = json.Unmarshal(mock.GetRefundListResponse(), &expected)
+
+	a.NoError(err)
+	a.Equal(expected, *resp)
+	a.Len(resp.Items, 2)
+}
+
+func TestRefunds_ListWithError(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	resp, res, err := c.Refunds.List(context.Background(), nil)
+
+	a.Error(err)
+	a.Nil(resp)
+	a.NotNil(res)
+	a.Equal(http.StatusInternalServerError, res.Status)
+}
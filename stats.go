@@ -83,7 +83,7 @@ func (s *StatsService) GetMetricsSummary(ctx context.Context, query *MetricsSumm
 	}
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,280 @@
+package creemio
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoRate is returned by a RateSource without a rate for the currencies.
+var ErrNoRate = errors.New("no exchange rate")
+
+// SupportedCurrencies are the currencies GetAggregateSummary queries unless
+// told otherwise.
+var SupportedCurrencies = []Currency{CurrencyEUR, CurrencyUSD}
+
+// RateSource supplies exchange rates.
+type RateSource interface {
+	// Rate returns how much one unit of from is worth in to.
+	Rate(ctx context.Context, from, to Currency) (float64, error)
+}
+
+// StaticRates is a fixed table of rates relative to Base: Rates[c] is the
+// amount of c one unit of Base buys.
+type StaticRates struct {
+	Base  Currency
+	Rates map[Currency]float64
+}
+
+// DefaultRates is an approximate table used when no RateSource is given.
+// Replace it for reporting that has to match the books.
+var DefaultRates = &StaticRates{
+	Base:  CurrencyEUR,
+	Rates: map[Currency]float64{CurrencyUSD: 1.08},
+}
+
+func (r *StaticRates) rate(c Currency) (float64, bool) {
+	if strings.EqualFold(string(c), string(r.Base)) {
+		return 1, true
+	}
+	for currency, rate := range r.Rates {
+		if strings.EqualFold(string(currency), string(c)) && rate > 0 {
+			return rate, true
+		}
+	}
+	return 0, false
+}
+
+func (r *StaticRates) Rate(ctx context.Context, from, to Currency) (float64, error) {
+	fromRate, ok := r.rate(from)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s to %s", ErrNoRate, from, to)
+	}
+	toRate, ok := r.rate(to)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s to %s", ErrNoRate, from, to)
+	}
+	return toRate / fromRate, nil
+}
+
+type ratePair struct {
+	from Currency
+	to   Currency
+}
+
+type orderRate struct {
+	rate float64
+	at   time.Time
+}
+
+// OrderRates derives rates from the FxRate of checkout orders paid in a
+// different currency than the product's. The most recent order of a
+// currency pair wins, the inverse pair is derived from it.
+type OrderRates struct {
+	mu    sync.RWMutex
+	rates map[ratePair]orderRate
+}
+
+func NewOrderRates(orders ...CheckoutOrder) *OrderRates {
+	r := &OrderRates{rates: make(map[ratePair]orderRate)}
+	for i := range orders {
+		r.Add(&orders[i])
+	}
+	return r
+}
+
+// Add records the rate of order, orders without one are ignored.
+func (r *OrderRates) Add(order *CheckoutOrder) {
+	if order == nil || order.FxRate <= 0 || len(order.Currency) == 0 || len(order.FxCurrency) == 0 {
+		return
+	}
+	from := Currency(strings.ToUpper(order.Currency))
+	to := Currency(strings.ToUpper(order.FxCurrency))
+	if from == to {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pair := ratePair{from, to}
+	if current, ok := r.rates[pair]; ok && current.at.After(order.CreatedAt) {
+		return
+	}
+	r.rates[pair] = orderRate{rate: order.FxRate, at: order.CreatedAt}
+}
+
+func (r *OrderRates) Rate(ctx context.Context, from, to Currency) (float64, error) {
+	from = Currency(strings.ToUpper(string(from)))
+	to = Currency(strings.ToUpper(string(to)))
+	if from == to {
+		return 1, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	direct, hasDirect := r.rates[ratePair{from, to}]
+	inverse, hasInverse := r.rates[ratePair{to, from}]
+	switch {
+	case hasDirect && (!hasInverse || !inverse.at.After(direct.at)):
+		return direct.rate, nil
+	case hasInverse:
+		return 1 / inverse.rate, nil
+	}
+	return 0, fmt.Errorf("%w: %s to %s", ErrNoRate, from, to)
+}
+
+// RateSources tries each source in order until one has a rate.
+type RateSources []RateSource
+
+func (s RateSources) Rate(ctx context.Context, from, to Currency) (float64, error) {
+	for _, source := range s {
+		rate, err := source.Rate(ctx, from, to)
+		if err == nil {
+			return rate, nil
+		}
+		if !errors.Is(err, ErrNoRate) {
+			return 0, err
+		}
+	}
+	return 0, fmt.Errorf("%w: %s to %s", ErrNoRate, from, to)
+}
+
+// currencyExponent returns the number of minor unit digits of c.
+func currencyExponent(c Currency) int {
+	if zeroDecimalCurrencies[strings.ToUpper(string(c))] {
+		return 0
+	}
+	return 2
+}
+
+type AggregateSummaryQuery struct {
+	// Currency is the reporting currency.
+	Currency Currency
+	// Currencies are queried concurrently, SupportedCurrencies when empty.
+	// They are upper-cased and deduplicated first.
+	Currencies []Currency
+	Interval   Interval
+	StartDate  int64
+	EndDate    int64
+	// Rates converts to Currency, DefaultRates when nil.
+	Rates RateSource
+}
+
+// AggregateSummary combines the summaries of several currencies.
+type AggregateSummary struct {
+	// MetricsSummary is in the reporting currency. Subscription and payment
+	// counts are summed, revenue is converted and periods are merged by
+	// timestamp. TotalProducts and TotalCustomers are left zero, as the same
+	// product or customer may be counted in several currencies, see
+	// ByCurrency.
+	MetricsSummary
+	Currency Currency
+	// ByCurrency are the summaries as reported.
+	ByCurrency map[Currency]*MetricsSummary
+	// Rates are the conversion factors applied to amounts in the smallest
+	// unit of each currency.
+	Rates map[Currency]float64
+}
+
+// GetAggregateSummary fetches the summary of every currency and converts
+// them to the reporting currency.
+func (s *StatsService) GetAggregateSummary(ctx context.Context, query *AggregateSummaryQuery) (*AggregateSummary, error) {
+	if query == nil || len(query.Currency) == 0 {
+		return nil, errRequiredFieldCurrency
+	}
+	currencies := SupportedCurrencies
+	if len(query.Currencies) > 0 {
+		// "eur" and "EUR" are the same summary, query it once.
+		currencies = make([]Currency, 0, len(query.Currencies))
+		for _, c := range query.Currencies {
+			c = Currency(strings.ToUpper(string(c)))
+			if !slices.Contains(currencies, c) {
+				currencies = append(currencies, c)
+			}
+		}
+	}
+	var rates RateSource = DefaultRates
+	if query.Rates != nil {
+		rates = query.Rates
+	}
+
+	// Resolve the rates first, so a missing one does not cost requests.
+	factors := make(map[Currency]float64, len(currencies))
+	for _, currency := range currencies {
+		rate, err := rates.Rate(ctx, currency, query.Currency)
+		if err != nil {
+			return nil, err
+		}
+		factors[currency] = rate * math.Pow10(currencyExponent(query.Currency)-currencyExponent(currency))
+	}
+
+	summaries := make([]*MetricsSummary, len(currencies))
+	errs := make([]error, len(currencies))
+	var wg sync.WaitGroup
+	for i, currency := range currencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			summary, _, err := s.GetMetricsSummary(ctx, &MetricsSummaryQuery{
+				Currency:  currency,
+				Interval:  query.Interval,
+				StartDate: query.StartDate,
+				EndDate:   query.EndDate,
+			})
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", currency, err)
+				return
+			}
+			summaries[i] = summary
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	result := &AggregateSummary{
+		Currency:   query.Currency,
+		ByCurrency: make(map[Currency]*MetricsSummary, len(currencies)),
+		Rates:      factors,
+	}
+	periods := make(map[int64]*Period)
+	for i, currency := range currencies {
+		rate := factors[currency]
+		summary := summaries[i]
+		result.ByCurrency[currency] = summary
+
+		t, total := &summary.Totals, &result.Totals
+		total.TotalSubscriptions += t.TotalSubscriptions
+		total.TotalPayments += t.TotalPayments
+		total.ActiveSubscriptions += t.ActiveSubscriptions
+		total.TotalRevenue += t.TotalRevenue * rate
+		total.TotalNetRevenue += t.TotalNetRevenue * rate
+		total.NetMonthlyRecurringRevenue += t.NetMonthlyRecurringRevenue * rate
+		total.MonthlyRecurringRevenue += t.MonthlyRecurringRevenue * rate
+
+		for _, p := range summary.Periods {
+			merged, ok := periods[p.Timestamp]
+			if !ok {
+				merged = &Period{Timestamp: p.Timestamp}
+				periods[p.Timestamp] = merged
+			}
+			merged.GrossRevenue += p.GrossRevenue * rate
+			merged.NetRevenue += p.NetRevenue * rate
+		}
+	}
+
+	for _, p := range periods {
+		result.Periods = append(result.Periods, *p)
+	}
+	slices.SortFunc(result.Periods, func(a, b Period) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+	return result, nil
+}
@@ -0,0 +1,131 @@
+package creemio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRates(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	rates := &StaticRates{Base: CurrencyEUR, Rates: map[Currency]float64{CurrencyUSD: 1.25, "GBP": 0.8}}
+
+	rate, err := rates.Rate(context.Background(), CurrencyEUR, CurrencyUSD)
+	a.NoError(err)
+	a.Equal(1.25, rate)
+
+	rate, err = rates.Rate(context.Background(), "usd", "GBP")
+	a.NoError(err)
+	a.InDelta(0.64, rate, 1e-9)
+
+	_, err = rates.Rate(context.Background(), "CHF", CurrencyEUR)
+	a.ErrorIs(err, ErrNoRate)
+}
+
+func TestOrderRates(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	now := time.Now()
+	rates := NewOrderRates(
+		CheckoutOrder{Currency: "EUR", FxCurrency: "USD", FxRate: 1.1, CreatedAt: now.Add(-time.Hour)},
+		CheckoutOrder{Currency: "EUR", FxCurrency: "USD", FxRate: 1.2, CreatedAt: now},
+		// Older, ignored.
+		CheckoutOrder{Currency: "EUR", FxCurrency: "USD", FxRate: 1.0, CreatedAt: now.Add(-2 * time.Hour)},
+		// Same currency and missing rates are ignored.
+		CheckoutOrder{Currency: "EUR", FxCurrency: "EUR", FxRate: 1},
+		CheckoutOrder{Currency: "USD", FxCurrency: "GBP"},
+	)
+
+	rate, err := rates.Rate(context.Background(), CurrencyEUR, CurrencyUSD)
+	a.NoError(err)
+	a.Equal(1.2, rate)
+
+	rate, err = rates.Rate(context.Background(), CurrencyUSD, CurrencyEUR)
+	a.NoError(err)
+	a.InDelta(1/1.2, rate, 1e-9)
+
+	_, err = rates.Rate(context.Background(), CurrencyUSD, "GBP")
+	a.ErrorIs(err, ErrNoRate)
+
+	// Falls back to the next source.
+	rate, err = RateSources{rates, &StaticRates{Base: CurrencyUSD, Rates: map[Currency]float64{"GBP": 0.75}}}.
+		Rate(context.Background(), CurrencyUSD, "GBP")
+	a.NoError(err)
+	a.Equal(0.75, rate)
+}
+
+func TestStats_GetAggregateSummary(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		a.Equal(string(IntervalMonth), r.URL.Query().Get("interval"))
+		summary := MetricsSummary{
+			Totals: Totals{TotalCustomers: 2, TotalSubscriptions: 2, MonthlyRecurringRevenue: 1000},
+			Periods: []Period{
+				{Timestamp: ms(2025, 2, 1), GrossRevenue: 100, NetRevenue: 80},
+			},
+		}
+		switch r.URL.Query().Get("currency") {
+		case "USD":
+			summary.Totals = Totals{TotalCustomers: 3, TotalSubscriptions: 3, MonthlyRecurringRevenue: 2000}
+			summary.Periods = append(summary.Periods, Period{Timestamp: ms(2025, 1, 1), GrossRevenue: 200, NetRevenue: 200})
+		case "JPY":
+			summary.Totals = Totals{MonthlyRecurringRevenue: 16000}
+			summary.Periods = nil
+		}
+		json.NewEncoder(w).Encode(summary)
+	}))
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+
+	result, err := c.Stats.GetAggregateSummary(context.Background(), &AggregateSummaryQuery{
+		Currency:   CurrencyEUR,
+		Currencies: []Currency{CurrencyEUR, CurrencyUSD, "JPY", "eur", "usd"},
+		Interval:   IntervalMonth,
+		Rates: &StaticRates{Base: CurrencyEUR, Rates: map[Currency]float64{
+			CurrencyUSD: 2,
+			"JPY":       160,
+		}},
+	})
+	a.NoError(err)
+
+	a.Equal(CurrencyEUR, result.Currency)
+	a.Equal(5, result.Totals.TotalSubscriptions)
+	// Customers may pay in several currencies.
+	a.Zero(result.Totals.TotalCustomers)
+	a.Equal(3, result.ByCurrency[CurrencyUSD].Totals.TotalCustomers)
+	// 1000 + 2000/2 + 16000 yen as 100 euros in cents.
+	a.InDelta(12000, result.Totals.MonthlyRecurringRevenue, 1e-6)
+	a.Equal([]Period{
+		{Timestamp: ms(2025, 1, 1), GrossRevenue: 100, NetRevenue: 100},
+		{Timestamp: ms(2025, 2, 1), GrossRevenue: 150, NetRevenue: 120},
+	}, result.Periods)
+	a.Equal(2000.0, result.ByCurrency[CurrencyUSD].Totals.MonthlyRecurringRevenue)
+	a.Equal(0.5, result.Rates[CurrencyUSD])
+	// Duplicates in another case are queried and counted once.
+	a.Equal(int32(3), requests.Load())
+	a.Len(result.ByCurrency, 3)
+	a.Len(result.Rates, 3)
+
+	_, err = c.Stats.GetAggregateSummary(context.Background(), &AggregateSummaryQuery{
+		Currency:   CurrencyEUR,
+		Currencies: []Currency{"CHF"},
+	})
+	a.ErrorIs(err, ErrNoRate)
+
+	_, err = c.Stats.GetAggregateSummary(context.Background(), nil)
+	a.ErrorIs(err, errRequiredFieldCurrency)
+}
@@ -0,0 +1,263 @@
+package creemio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	errUnknownInterval = errors.New("unknown interval")
+	errInvalidRange    = errors.New("start must be before end")
+)
+
+// Time returns Timestamp, given in Unix milliseconds, as a time.
+func (p *Period) Time() time.Time {
+	return time.UnixMilli(p.Timestamp)
+}
+
+// BucketStart returns the start of the interval containing t in loc. Weeks
+// start on Monday. A nil loc is UTC.
+func BucketStart(t time.Time, interval Interval, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+
+	switch interval {
+	case IntervalDay:
+		return day, nil
+	case IntervalWeek:
+		// Sunday is 0, move it to the end of the week.
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset), nil
+	case IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", errUnknownInterval, interval)
+}
+
+func nextBucket(t time.Time, interval Interval) time.Time {
+	switch interval {
+	case IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// FillPeriods returns one period per interval from the bucket containing
+// start up to end, in loc. Periods in the same bucket are summed and
+// missing buckets are zero, so a day series can be resampled to weeks or
+// months. Periods outside the range are dropped.
+func FillPeriods(periods []Period, interval Interval, start, end time.Time, loc *time.Location) ([]Period, error) {
+	if !start.Before(end) {
+		return nil, errInvalidRange
+	}
+	first, err := BucketStart(start, interval, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	var filled []Period
+	index := make(map[int64]int)
+	for t := first; t.Before(end); t = nextBucket(t, interval) {
+		index[t.UnixMilli()] = len(filled)
+		filled = append(filled, Period{Timestamp: t.UnixMilli()})
+	}
+
+	for _, p := range periods {
+		bucket, _ := BucketStart(p.Time(), interval, loc)
+		i, ok := index[bucket.UnixMilli()]
+		if !ok {
+			continue
+		}
+		filled[i].GrossRevenue += p.GrossRevenue
+		filled[i].NetRevenue += p.NetRevenue
+	}
+	return filled, nil
+}
+
+// ResamplePeriods sums periods into buckets of interval in loc, filling
+// the gaps between the first and the last period.
+func ResamplePeriods(periods []Period, interval Interval, loc *time.Location) ([]Period, error) {
+	if len(periods) == 0 {
+		return nil, nil
+	}
+	first, last := periods[0].Time(), periods[0].Time()
+	for _, p := range periods[1:] {
+		if t := p.Time(); t.Before(first) {
+			first = t
+		} else if t.After(last) {
+			last = t
+		}
+	}
+	return FillPeriods(periods, interval, first, last.Add(time.Millisecond), loc)
+}
+
+// Growth returns the change from previous to current in percent. It
+// returns false when previous is zero.
+func Growth(current, previous float64) (float64, bool) {
+	if previous == 0 {
+		return 0, false
+	}
+	return (current - previous) / math.Abs(previous) * 100, true
+}
+
+// SeriesQuery selects a range of revenue buckets.
+type SeriesQuery struct {
+	Currency Currency
+	Interval Interval
+	// Start is inclusive, End exclusive.
+	Start time.Time
+	End   time.Time
+	// Location sets the bucket boundaries, UTC when nil. Outside UTC the
+	// summary is fetched by UTC day and resampled, as the API buckets in
+	// UTC. A UTC day cannot be split, so it is assigned to the local day
+	// holding most of it and local buckets are approximate.
+	Location *time.Location
+}
+
+// Series is a filled range of revenue buckets.
+type Series struct {
+	Start   time.Time
+	End     time.Time
+	Periods []Period
+}
+
+// GrossRevenue sums the gross revenue of all periods.
+func (s *Series) GrossRevenue() float64 {
+	var sum float64
+	for _, p := range s.Periods {
+		sum += p.GrossRevenue
+	}
+	return sum
+}
+
+// NetRevenue sums the net revenue of all periods.
+func (s *Series) NetRevenue() float64 {
+	var sum float64
+	for _, p := range s.Periods {
+		sum += p.NetRevenue
+	}
+	return sum
+}
+
+// Comparison is a range and the range preceding it.
+type Comparison struct {
+	Current  *Series
+	Previous *Series
+	// Totals are reported with the current range.
+	Totals Totals
+	// GrossRevenueGrowth and NetRevenueGrowth compare the revenue of the
+	// ranges in percent, zero when the previous range had none.
+	GrossRevenueGrowth float64
+	NetRevenueGrowth   float64
+}
+
+// GetSeries fetches the revenue of the range and fills missing buckets.
+func (s *StatsService) GetSeries(ctx context.Context, query *SeriesQuery) (*Series, *Totals, error) {
+	if query == nil || len(query.Currency) == 0 {
+		return nil, nil, errRequiredFieldCurrency
+	}
+	if !query.Start.Before(query.End) {
+		return nil, nil, errInvalidRange
+	}
+	if _, err := BucketStart(query.Start, query.Interval, query.Location); err != nil {
+		return nil, nil, err
+	}
+
+	interval := query.Interval
+	local := query.Location != nil && query.Location != time.UTC
+	if local {
+		interval = IntervalDay
+	}
+
+	summary, _, err := s.GetMetricsSummary(ctx, &MetricsSummaryQuery{
+		Currency:  query.Currency,
+		Interval:  interval,
+		StartDate: query.Start.UnixMilli(),
+		EndDate:   query.End.UnixMilli(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	periods := summary.Periods
+	if local {
+		// Bucket each UTC day by its midpoint, which lies in the local day
+		// holding most of it.
+		periods = make([]Period, len(summary.Periods))
+		for i, p := range summary.Periods {
+			p.Timestamp += (12 * time.Hour).Milliseconds()
+			periods[i] = p
+		}
+	}
+
+	periods, err = FillPeriods(periods, query.Interval, query.Start, query.End, query.Location)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Series{Start: query.Start, End: query.End, Periods: periods}, &summary.Totals, nil
+}
+
+// precedingRange returns the range of the same length ending at start.
+// Month ranges are shifted by whole months so their buckets line up.
+func precedingRange(start, end time.Time, interval Interval, loc *time.Location) (time.Time, time.Time) {
+	if interval == IntervalMonth {
+		if loc == nil {
+			loc = time.UTC
+		}
+		s, e := start.In(loc), end.In(loc)
+		months := (e.Year()-s.Year())*12 + int(e.Month()-s.Month())
+		if months > 0 && s.Equal(time.Date(s.Year(), s.Month(), 1, 0, 0, 0, 0, loc)) {
+			return s.AddDate(0, -months, 0), start
+		}
+	}
+	return start.Add(-end.Sub(start)), start
+}
+
+// Compare fetches the range of query and the range of the same length
+// preceding it concurrently, and compares their revenue.
+func (s *StatsService) Compare(ctx context.Context, query *SeriesQuery) (*Comparison, error) {
+	if query == nil {
+		return nil, errRequiredFieldCurrency
+	}
+	previous := *query
+	previous.Start, previous.End = precedingRange(query.Start, query.End, query.Interval, query.Location)
+
+	var (
+		wg      sync.WaitGroup
+		cmp     Comparison
+		totals  *Totals
+		currErr error
+		prevErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		cmp.Current, totals, currErr = s.GetSeries(ctx, query)
+	}()
+	go func() {
+		defer wg.Done()
+		cmp.Previous, _, prevErr = s.GetSeries(ctx, &previous)
+	}()
+	wg.Wait()
+
+	if currErr != nil {
+		return nil, currErr
+	}
+	if prevErr != nil {
+		return nil, prevErr
+	}
+
+	cmp.Totals = *totals
+	cmp.GrossRevenueGrowth, _ = Growth(cmp.Current.GrossRevenue(), cmp.Previous.GrossRevenue())
+	cmp.NetRevenueGrowth, _ = Growth(cmp.Current.NetRevenue(), cmp.Previous.NetRevenue())
+	return &cmp, nil
+}
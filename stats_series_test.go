@@ -0,0 +1,207 @@
+package creemio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ms(year int, month time.Month, day int) int64 {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).UnixMilli()
+}
+
+func TestBucketStart(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	// Sunday evening in UTC is already Monday in UTC+2.
+	at := time.Date(2025, 3, 9, 23, 0, 0, 0, time.UTC)
+	cet := time.FixedZone("UTC+2", 2*60*60)
+
+	start, err := BucketStart(at, IntervalWeek, nil)
+	a.NoError(err)
+	a.Equal(time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), start)
+
+	start, err = BucketStart(at, IntervalWeek, cet)
+	a.NoError(err)
+	a.True(time.Date(2025, 3, 10, 0, 0, 0, 0, cet).Equal(start))
+
+	start, err = BucketStart(at, IntervalMonth, nil)
+	a.NoError(err)
+	a.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), start)
+
+	_, err = BucketStart(at, "year", nil)
+	a.ErrorIs(err, errUnknownInterval)
+}
+
+func TestFillPeriods(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	periods := []Period{
+		{Timestamp: ms(2025, 3, 3), GrossRevenue: 100, NetRevenue: 90},
+		{Timestamp: ms(2025, 3, 5), GrossRevenue: 50, NetRevenue: 45},
+		{Timestamp: ms(2025, 3, 12), GrossRevenue: 10, NetRevenue: 9},
+	}
+
+	filled, err := FillPeriods(periods, IntervalDay, time.UnixMilli(ms(2025, 3, 3)), time.UnixMilli(ms(2025, 3, 6)), nil)
+	a.NoError(err)
+	a.Equal([]Period{
+		{Timestamp: ms(2025, 3, 3), GrossRevenue: 100, NetRevenue: 90},
+		{Timestamp: ms(2025, 3, 4)},
+		{Timestamp: ms(2025, 3, 5), GrossRevenue: 50, NetRevenue: 45},
+	}, filled)
+
+	weeks, err := ResamplePeriods(periods, IntervalWeek, nil)
+	a.NoError(err)
+	a.Equal([]Period{
+		{Timestamp: ms(2025, 3, 3), GrossRevenue: 150, NetRevenue: 135},
+		{Timestamp: ms(2025, 3, 10), GrossRevenue: 10, NetRevenue: 9},
+	}, weeks)
+
+	months, err := ResamplePeriods(weeks, IntervalMonth, nil)
+	a.NoError(err)
+	a.Equal([]Period{{Timestamp: ms(2025, 3, 1), GrossRevenue: 160, NetRevenue: 144}}, months)
+
+	_, err = FillPeriods(periods, IntervalDay, time.UnixMilli(ms(2025, 3, 6)), time.UnixMilli(ms(2025, 3, 3)), nil)
+	a.ErrorIs(err, errInvalidRange)
+}
+
+func TestGrowth(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	growth, ok := Growth(150, 100)
+	a.True(ok)
+	a.Equal(50.0, growth)
+
+	growth, ok = Growth(50, 100)
+	a.True(ok)
+	a.Equal(-50.0, growth)
+
+	_, ok = Growth(50, 0)
+	a.False(ok)
+}
+
+func TestStats_Compare(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var mu sync.Mutex
+	starts := map[string]string{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		mu.Lock()
+		starts[q.Get("start_date")] = q.Get("end_date")
+		mu.Unlock()
+		a.Equal(string(IntervalMonth), q.Get("interval"))
+
+		start, _ := strconv.ParseInt(q.Get("start_date"), 10, 64)
+		revenue := 100.0
+		if start == ms(2025, 3, 1) {
+			revenue = 125
+		}
+		// February is missing from the previous range.
+		json.NewEncoder(w).Encode(MetricsSummary{
+			Totals:  Totals{MonthlyRecurringRevenue: 1000},
+			Periods: []Period{{Timestamp: start, GrossRevenue: revenue, NetRevenue: revenue / 2}},
+		})
+	}))
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+
+	cmp, err := c.Stats.Compare(context.Background(), &SeriesQuery{
+		Currency: CurrencyUSD,
+		Interval: IntervalMonth,
+		Start:    time.UnixMilli(ms(2025, 3, 1)).UTC(),
+		End:      time.UnixMilli(ms(2025, 5, 1)).UTC(),
+	})
+	a.NoError(err)
+
+	a.Equal(map[string]string{
+		strconv.FormatInt(ms(2025, 3, 1), 10): strconv.FormatInt(ms(2025, 5, 1), 10),
+		strconv.FormatInt(ms(2025, 1, 1), 10): strconv.FormatInt(ms(2025, 3, 1), 10),
+	}, starts)
+	a.Equal([]Period{
+		{Timestamp: ms(2025, 1, 1), GrossRevenue: 100, NetRevenue: 50},
+		{Timestamp: ms(2025, 2, 1)},
+	}, cmp.Previous.Periods)
+	a.Len(cmp.Current.Periods, 2)
+	a.Equal(25.0, cmp.GrossRevenueGrowth)
+	a.Equal(25.0, cmp.NetRevenueGrowth)
+	a.Equal(1000.0, cmp.Totals.MonthlyRecurringRevenue)
+
+	_, err = c.Stats.Compare(context.Background(), &SeriesQuery{Interval: IntervalMonth})
+	a.ErrorIs(err, errRequiredFieldCurrency)
+}
+
+func TestStats_GetSeriesInLocation(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal(string(IntervalDay), r.URL.Query().Get("interval"))
+		json.NewEncoder(w).Encode(MetricsSummary{})
+	}))
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+	loc := time.FixedZone("UTC-5", -5*60*60)
+
+	series, _, err := c.Stats.GetSeries(context.Background(), &SeriesQuery{
+		Currency: CurrencyEUR,
+		Interval: IntervalWeek,
+		Start:    time.Date(2025, 3, 3, 0, 0, 0, 0, loc),
+		End:      time.Date(2025, 3, 17, 0, 0, 0, 0, loc),
+		Location: loc,
+	})
+	a.NoError(err)
+	a.Len(series.Periods, 2)
+	a.True(time.Date(2025, 3, 10, 0, 0, 0, 0, loc).Equal(series.Periods[1].Time()))
+}
+
+func TestStats_GetSeriesDayInLocation(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(MetricsSummary{Periods: []Period{
+			{Timestamp: ms(2025, 1, 2), GrossRevenue: 100},
+			{Timestamp: ms(2025, 1, 3), GrossRevenue: 200},
+		}})
+	}))
+	defer s.Close()
+
+	c := New(WithBaseURL(s.URL))
+
+	// UTC days are assigned to the local day holding most of them, whether
+	// the zone is behind or ahead of UTC.
+	for _, loc := range []*time.Location{
+		time.FixedZone("UTC-5", -5*60*60),
+		time.FixedZone("UTC+9", 9*60*60),
+	} {
+		t.Run(loc.String(), func(t *testing.T) {
+			a := assert.New(t)
+
+			series, _, err := c.Stats.GetSeries(context.Background(), &SeriesQuery{
+				Currency: CurrencyEUR,
+				Interval: IntervalDay,
+				Start:    time.Date(2025, 1, 2, 0, 0, 0, 0, loc),
+				End:      time.Date(2025, 1, 4, 0, 0, 0, 0, loc),
+				Location: loc,
+			})
+			a.NoError(err)
+			a.Equal([]Period{
+				{Timestamp: time.Date(2025, 1, 2, 0, 0, 0, 0, loc).UnixMilli(), GrossRevenue: 100},
+				{Timestamp: time.Date(2025, 1, 3, 0, 0, 0, 0, loc).UnixMilli(), GrossRevenue: 200},
+			}, series.Periods)
+		})
+	}
+}
@@ -112,7 +112,7 @@ func (s *SubscriptionService) Get(ctx context.Context, id string) (*Subscription
 	q.Set("subscription_id", id)
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -134,6 +134,12 @@ func (s *SubscriptionService) Get(ctx context.Context, id string) (*Subscription
 	return &sub, newResponse(res, body), nil
 }
 
+// GetMany fetches the subscriptions with the given IDs concurrently, see BatchOptions.
+// Results are in the order of ids, each with its own error.
+func (s *SubscriptionService) GetMany(ctx context.Context, ids []string, opts *BatchOptions) []BatchResult[Subscription] {
+	return getMany(ctx, ids, opts, s.Get)
+}
+
 func (s *SubscriptionService) Update(ctx context.Context, data *UpdateSubscriptionRequest) (*Subscription, *Response, error) {
 	if len(data.SubscriptionID) == 0 {
 		return nil, nil, errRequiredFieldSubscriptionID
@@ -153,7 +159,15 @@ func (s *SubscriptionService) Update(ctx context.Context, data *UpdateSubscripti
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-api-key", s.client.apiKey)
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service:    "subscriptions",
+		action:     "update",
+		resourceID: data.SubscriptionID,
+		guarded:    true,
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateUpdate(ctx, data)
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -185,7 +199,15 @@ func (s *SubscriptionService) Cancel(ctx context.Context, id string) (*Subscript
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-api-key", s.client.apiKey)
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service:    "subscriptions",
+		action:     "cancel",
+		resourceID: id,
+		guarded:    true,
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateStatus(ctx, id, SubscriptionStatusCanceled)
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -226,7 +248,15 @@ func (s *SubscriptionService) Upgrade(ctx context.Context, data *UpgradeSubscrip
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-api-key", s.client.apiKey)
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service:    "subscriptions",
+		action:     "upgrade",
+		resourceID: data.SubscriptionID,
+		guarded:    true,
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateUpgrade(ctx, data)
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -258,7 +288,15 @@ func (s *SubscriptionService) Pause(ctx context.Context, id string) (*Subscripti
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-api-key", s.client.apiKey)
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service:    "subscriptions",
+		action:     "pause",
+		resourceID: id,
+		guarded:    true,
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateStatus(ctx, id, SubscriptionStatusPaused)
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -290,7 +328,15 @@ func (s *SubscriptionService) Resume(ctx context.Context, id string) (*Subscript
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-api-key", s.client.apiKey)
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.doMutation(req, mutation{
+		service:    "subscriptions",
+		action:     "resume",
+		resourceID: id,
+		guarded:    true,
+		simulate: func(ctx context.Context) (any, error) {
+			return s.simulateStatus(ctx, id, SubscriptionStatusActive)
+		},
+	})
 	if err != nil {
 		return nil, nil, err
 	}
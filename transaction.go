@@ -4,8 +4,32 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"iter"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+)
+
+type TransactionStatus string
+
+const (
+	TransactionStatusPending       = "pending"
+	TransactionStatusPaid          = "paid"
+	TransactionStatusRefunded      = "refunded"
+	TransactionStatusPartialRefund = "partialRefund"
+	TransactionStatusChargedBack   = "chargedBack"
+	TransactionStatusUncollectible = "uncollectible"
+	TransactionStatusDeclined      = "declined"
+	TransactionStatusVoid          = "void"
+)
+
+type TransactionType string
+
+const (
+	TransactionTypePayment = "payment"
+	TransactionTypeInvoice = "invoice"
 )
 
 type Transaction struct {
@@ -30,6 +54,11 @@ type Transaction struct {
 	CreatedAt      int    `json:"created_at"`
 }
 
+// CreatedTime returns CreatedAt, given in Unix milliseconds, as a time.
+func (t *Transaction) CreatedTime() time.Time {
+	return time.UnixMilli(int64(t.CreatedAt))
+}
+
 type TransactionList struct {
 	Items      []Transaction `json:"items"`
 	Pagination Pagination    `json:"pagination"`
@@ -41,6 +70,44 @@ type TransactionListQuery struct {
 	ProductID  string
 	PageNumber int
 	PageSize   int
+
+	// The API cannot filter by the fields below, they are applied to the
+	// items of every page instead. Pages may therefore hold fewer items
+	// than PageSize and Pagination still counts the unfiltered items, use
+	// All to iterate over every match.
+	SubscriptionID string
+	Status         TransactionStatus
+	Type           TransactionType
+	// Currency is compared case-insensitively.
+	Currency string
+	// CreatedAfter and CreatedBefore bound the creation time, CreatedAfter
+	// is inclusive. Zero values are ignored.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// matches reports whether t passes the client-side filters of q.
+func (q *TransactionListQuery) matches(t *Transaction) bool {
+	if len(q.SubscriptionID) > 0 && t.Subscription != q.SubscriptionID {
+		return false
+	}
+	if len(q.Status) > 0 && t.Status != string(q.Status) {
+		return false
+	}
+	if len(q.Type) > 0 && t.Type != string(q.Type) {
+		return false
+	}
+	if len(q.Currency) > 0 && !strings.EqualFold(t.Currency, q.Currency) {
+		return false
+	}
+	created := t.CreatedTime()
+	if !q.CreatedAfter.IsZero() && created.Before(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !created.Before(q.CreatedBefore) {
+		return false
+	}
+	return true
 }
 
 type TransactionService struct {
@@ -61,7 +128,7 @@ func (s *TransactionService) Get(ctx context.Context, id string) (*Transaction,
 	q.Set("transaction_id", id)
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -83,6 +150,12 @@ func (s *TransactionService) Get(ctx context.Context, id string) (*Transaction,
 	return &transaction, newResponse(res, body), nil
 }
 
+// GetMany fetches the transactions with the given IDs concurrently, see BatchOptions.
+// Results are in the order of ids, each with its own error.
+func (s *TransactionService) GetMany(ctx context.Context, ids []string, opts *BatchOptions) []BatchResult[Transaction] {
+	return getMany(ctx, ids, opts, s.Get)
+}
+
 func (s *TransactionService) List(ctx context.Context, query *TransactionListQuery) (*TransactionList, *Response, error) {
 	targetUrl := makeUrl(s.client.baseURL, "/transactions", "search")
 
@@ -113,7 +186,7 @@ func (s *TransactionService) List(ctx context.Context, query *TransactionListQue
 		req.URL.RawQuery = q.Encode()
 	}
 
-	res, err := s.client.httpClient.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -131,6 +204,40 @@ func (s *TransactionService) List(ctx context.Context, query *TransactionListQue
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, newResponse(res, body), err
 	}
+	if query != nil {
+		result.Items = slices.DeleteFunc(result.Items, func(t Transaction) bool {
+			return !query.matches(&t)
+		})
+	}
 
 	return &result, newResponse(res, body), nil
 }
+
+// All iterates over the transactions matching query on every page, starting
+// at query.PageNumber. Iteration stops at the first error.
+func (s *TransactionService) All(ctx context.Context, query *TransactionListQuery) iter.Seq2[Transaction, error] {
+	q := TransactionListQuery{}
+	if query != nil {
+		q = *query
+	}
+	return paginate(q.PageNumber, func(page int) ([]Transaction, Pagination, error) {
+		q.PageNumber = page
+		list, _, err := s.List(ctx, &q)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+}
+
+// listAll collects every transaction of All.
+func (s *TransactionService) listAll(ctx context.Context, query *TransactionListQuery) ([]Transaction, error) {
+	var transactions []Transaction
+	for t, err := range s.All(ctx, query) {
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, t)
+	}
+	return transactions, nil
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/evolvedevlab/creemio-go/mock"
 	"github.com/stretchr/testify/assert"
@@ -147,3 +148,75 @@ func TestTransaction_GetWithError(t *testing.T) {
 	a.NotNil(res)
 	a.Equal(http.StatusInternalServerError, res.Status)
 }
+
+func TestTransactions_ListFilters(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	day := func(d int) int {
+		return int(time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC).UnixMilli())
+	}
+	pages := map[string][]Transaction{
+		"1": {
+			{ID: "txn_1", Status: TransactionStatusPaid, Type: TransactionTypePayment, Currency: "USD", Subscription: "sub_1", CreatedAt: day(1)},
+			{ID: "txn_2", Status: TransactionStatusRefunded, Type: TransactionTypePayment, Currency: "USD", Subscription: "sub_1", CreatedAt: day(2)},
+		},
+		"2": {
+			{ID: "txn_3", Status: TransactionStatusPaid, Type: TransactionTypeInvoice, Currency: "EUR", Subscription: "sub_2", CreatedAt: day(3)},
+		},
+		"3": {
+			{ID: "txn_4", Status: TransactionStatusPaid, Type: TransactionTypePayment, Currency: "usd", Subscription: "sub_1", CreatedAt: day(4)},
+			{ID: "txn_5", Status: TransactionStatusPaid, Type: TransactionTypePayment, Currency: "USD", Subscription: "sub_1", CreatedAt: day(31)},
+		},
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		// Client-side filters are not sent.
+		a.Empty(q.Get("status"))
+		a.Equal("cus_123", q.Get("customer_id"))
+
+		page := q.Get("page_number")
+		n, _ := strconv.Atoi(page)
+		next := n + 1
+		if n == 3 {
+			next = 0
+		}
+		json.NewEncoder(w).Encode(TransactionList{
+			Items:      pages[page],
+			Pagination: Pagination{CurrentPage: n, NextPage: next},
+		})
+	}))
+	defer s.Close()
+
+	c := New(
+		WithBaseURL(s.URL),
+		WithAPIKey(""),
+	)
+
+	query := &TransactionListQuery{
+		CustomerID:     "cus_123",
+		SubscriptionID: "sub_1",
+		Status:         TransactionStatusPaid,
+		Type:           TransactionTypePayment,
+		Currency:       "USD",
+		CreatedAfter:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBefore:  time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	// A single page is filtered in place.
+	first := *query
+	first.PageNumber = 1
+	resp, _, err := c.Transactions.List(context.Background(), &first)
+	a.NoError(err)
+	a.Len(resp.Items, 1)
+	a.Equal("txn_1", resp.Items[0].ID)
+
+	// All follows the pagination, including pages without matches.
+	var ids []string
+	for txn, err := range c.Transactions.All(context.Background(), query) {
+		a.NoError(err)
+		ids = append(ids, txn.ID)
+	}
+	a.Equal([]string{"txn_1", "txn_4"}, ids)
+}
@@ -1,5 +1,14 @@
 package creemio
 
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// WebHookSignatureHeader is the header carrying the webhook signature.
+const WebHookSignatureHeader = "creem-signature"
+
 type WebHookEvent string
 
 const (
@@ -77,3 +86,17 @@ type Dispute struct {
 	CreatedAt    int64          `json:"created_at"`
 	Mode         Mode           `json:"mode"`
 }
+
+// VerifyWebHookSignature reports whether signature is the HMAC-SHA256 of body
+// signed with the webhook secret generated in the dashboard.
+func VerifyWebHookSignature(body []byte, signature, secret string) bool {
+	if len(signature) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expectedSig := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(signature), []byte(expectedSig))
+}